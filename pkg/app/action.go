@@ -3,6 +3,9 @@ package app
 import (
 	"fmt"
 	"sync"
+	"time"
+
+	"github.com/maxence-charriere/go-app/v10/pkg/errors"
 )
 
 // Action represents a custom event that can be propagated across the app. It
@@ -16,6 +19,8 @@ type Action struct {
 
 	// Tags provide additional context or metadata for the action.
 	Tags Tags
+
+	broadcasted bool
 }
 
 // ActionHandler defines a callback executed when an action is triggered
@@ -30,21 +35,143 @@ func Handle(actionName string, h ActionHandler) {
 
 var actionHandlers = make(map[string]ActionHandler)
 
+// HandleWithPolicy registers the provided handler for a specific action name,
+// and limits how often it executes with the given policy. When that action is
+// triggered, the handler executes in a separate goroutine.
+func HandleWithPolicy(actionName string, h ActionHandler, p ActionPolicy) {
+	actionHandlers[actionName] = h
+	actionPolicies[actionName] = p
+}
+
+var actionPolicies = make(map[string]ActionPolicy)
+
+// ActionPolicy defines how often an action handler executes when its action
+// is posted at a high frequency. The zero value executes the handler for each
+// posted action.
+type ActionPolicy struct {
+	// Debounce delays the handler execution until no action was posted for the
+	// given duration. The handler is executed with the latest action.
+	Debounce time.Duration
+
+	// Throttle executes the handler at most once per given duration. An action
+	// posted while the handler is throttled is handled at the end of the
+	// duration, with the latest action posted in the meantime.
+	Throttle time.Duration
+
+	// Coalesce replaces the action of a pending handler execution with the
+	// latest action instead of scheduling another execution.
+	Coalesce bool
+
+	// MaxQueueLength is the maximum number of pending handler executions.
+	// Actions posted when it is reached are dropped. 0 means no limit.
+	MaxQueueLength int
+}
+
+func (p ActionPolicy) isZero() bool {
+	return p == ActionPolicy{}
+}
+
+// RequestHandler defines a callback that replies to a request action posted
+// with Context.Request or Context.RequestAndWait.
+type RequestHandler func(Context, Action) (any, error)
+
+// HandleRequest registers the provided handler to reply to a specific request
+// action. When that action is requested, the handler executes in a separate
+// goroutine.
+func HandleRequest(actionName string, h RequestHandler) {
+	requestHandlers[actionName] = h
+}
+
+var requestHandlers = make(map[string]RequestHandler)
+
+var (
+	// ErrRequestTimeout is returned when a request action is not replied
+	// within the requested timeout.
+	ErrRequestTimeout = errors.New("request timeout")
+
+	// ErrNoRequestHandler is returned when a request action has no handler.
+	ErrNoRequestHandler = errors.New("no request handler")
+
+	// ErrMultipleRequestHandlers is returned when a request action has several
+	// handlers, which makes the reply ambiguous. None of the handlers is
+	// executed.
+	ErrMultipleRequestHandlers = errors.New("multiple request handlers")
+)
+
+// ActionMiddleware intercepts an action before it reaches the action handlers.
+// It calls next to pass the action, which can be modified, to the following
+// middlewares and the handlers. The action is dropped when next is not called.
+// next can be called later, including from another goroutine, to delay the
+// action.
+type ActionMiddleware func(ctx Context, a Action, next func(Action))
+
+// UseActionMiddleware registers a middleware that intercepts all the actions
+// before they are handled. Middlewares are called in registration order.
+//
+// Example:
+//
+//	app.UseActionMiddleware(func(ctx app.Context, a app.Action, next func(app.Action)) {
+//	    app.Log("action", a.Name)
+//	    next(a)
+//	})
+func UseActionMiddleware(m ActionMiddleware) {
+	actionMiddlewares = append(actionMiddlewares, m)
+}
+
+var actionMiddlewares []ActionMiddleware
+
+// BroadcastActions enables broadcasting the actions with the given names to
+// the other browser tabs or windows of the same origin, where they are handled
+// by the handlers registered with Handle or Context.Handle. Broadcasted action
+// values are JSON-encoded and received as json.RawMessage.
+//
+// It relies on the BroadcastChannel used to broadcast states, and falls back
+// to local storage events when BroadcastChannel is not supported. It must be
+// called in all the tabs, before the app starts.
+//
+// Example:
+//
+//	app.BroadcastActions("/logout")
+func BroadcastActions(actionNames ...string) {
+	for _, name := range actionNames {
+		broadcastedActions[name] = true
+	}
+}
+
+var broadcastedActions = make(map[string]bool)
+
 type actionHandler struct {
 	Source   UI
 	Function ActionHandler
+	Request  RequestHandler
 	Async    bool
+	Limiter  *actionLimiter
 }
 
 // actionManager manages the registration and execution of action handlers. It
 // ensures that only actions related to mounted sources are processed.
 type actionManager struct {
-	mutex    sync.Mutex
-	handlers map[string]map[string]actionHandler
+	mutex                sync.Mutex
+	handlers             map[string]map[string]actionHandler
+	requestHandlers      map[string]map[string]actionHandler
+	middlewares          []ActionMiddleware
+	componentMiddlewares map[UI][]actionMiddleware
+	broadcasts           map[string]bool
+}
+
+type actionMiddleware struct {
+	key      string
+	function ActionMiddleware
 }
 
 // Handle registers an ActionHandler for the given action and source.
 func (m *actionManager) Handle(action string, source UI, async bool, handler ActionHandler) {
+	m.HandleWithPolicy(action, source, async, handler, ActionPolicy{})
+}
+
+// HandleWithPolicy registers an ActionHandler for the given action and source,
+// whose executions are limited by the given policy.
+func (m *actionManager) HandleWithPolicy(action string, source UI, async bool, handler ActionHandler, policy ActionPolicy) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -59,40 +186,241 @@ func (m *actionManager) Handle(action string, source UI, async bool, handler Act
 	}
 
 	key := actionHandlerKey(source, handler)
+
+	var limiter *actionLimiter
+	if current := handlers[key].Limiter; current != nil && current.policy == policy {
+		limiter = current
+	} else if !policy.isZero() {
+		limiter = &actionLimiter{policy: policy}
+	}
+
 	handlers[key] = actionHandler{
 		Source:   source,
 		Function: handler,
 		Async:    async,
+		Limiter:  limiter,
 	}
 }
 
-// Post processes the provided action by executing its associated handlers.
-func (m *actionManager) Post(ctx Context, a Action) {
+// HandleRequest registers a RequestHandler for the given request action and
+// source.
+func (m *actionManager) HandleRequest(action string, source UI, async bool, handler RequestHandler) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.requestHandlers == nil {
+		m.requestHandlers = make(map[string]map[string]actionHandler)
+	}
+
+	handlers, ok := m.requestHandlers[action]
+	if !ok {
+		handlers = make(map[string]actionHandler)
+		m.requestHandlers[action] = handlers
+	}
+
+	key := actionHandlerKey(source, handler)
+	handlers[key] = actionHandler{
+		Source:  source,
+		Request: handler,
+		Async:   async,
+	}
+}
+
+// Request processes the provided request action by passing it through the
+// middlewares, then executing its handler. The reply function is called once,
+// with the handler result or an error when the request cannot be handled or
+// is not replied within the timeout. A timeout of 0 or less waits
+// indefinitely.
+func (m *actionManager) Request(ctx Context, a Action, timeout time.Duration, reply func(any, error)) {
+	var once sync.Once
+	replied := make(chan struct{})
+	replyOnce := func(v any, err error) {
+		once.Do(func() {
+			close(replied)
+			reply(v, err)
+		})
+	}
+	requestErr := errors.New("requesting action failed").WithTag("action", a.Name)
+
+	if timeout > 0 {
+		go func() {
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+				replyOnce(nil, requestErr.
+					WithTag("timeout", timeout).
+					Wrap(ErrRequestTimeout))
+
+			case <-replied:
+			}
+		}()
+	}
+
+	runActionMiddlewares(ctx, m.middlewares, a, func(a Action) {
+		m.mutex.Lock()
+		var handlers []actionHandler
+		for key, handler := range m.requestHandlers[a.Name] {
+			if !handler.Source.Mounted() {
+				delete(m.requestHandlers[a.Name], key)
+				continue
+			}
+			handlers = append(handlers, handler)
+		}
+
+		var middlewares []ActionMiddleware
+		if len(handlers) == 1 {
+			for _, mw := range m.componentMiddlewares[handlers[0].Source] {
+				middlewares = append(middlewares, mw.function)
+			}
+		}
+		m.mutex.Unlock()
+
+		switch len(handlers) {
+		case 0:
+			replyOnce(nil, requestErr.Wrap(ErrNoRequestHandler))
+			return
+
+		case 1:
+
+		default:
+			replyOnce(nil, requestErr.
+				WithTag("handlers", len(handlers)).
+				Wrap(ErrMultipleRequestHandlers))
+			return
+		}
+
+		handler := handlers[0]
+		ctx.sourceElement = handler.Source
+
+		runActionMiddlewares(ctx, middlewares, a, func(a Action) {
+			handle := func(ctx Context) {
+				v, err := handler.Request(ctx, a)
+				replyOnce(v, err)
+			}
+
+			if handler.Async {
+				ctx.Async(func() {
+					handle(ctx)
+				})
+				return
+			}
+			ctx.dispatchOrDrop(handle, func() {
+				replyOnce(nil, requestErr.
+					WithTag("reason", "handler source dismounted").
+					Wrap(ErrNoRequestHandler))
+			})
+		})
+	})
+}
+
+// Use registers a middleware that intercepts the actions before they reach the
+// handlers of the given source. Registering the same middleware again for a
+// source has no effect.
+func (m *actionManager) Use(source UI, middleware ActionMiddleware) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
+	if m.componentMiddlewares == nil {
+		m.componentMiddlewares = make(map[UI][]actionMiddleware)
+	}
+
+	key := fmt.Sprintf("%p", middleware)
+	for _, mw := range m.componentMiddlewares[source] {
+		if mw.key == key {
+			return
+		}
+	}
+	m.componentMiddlewares[source] = append(m.componentMiddlewares[source], actionMiddleware{
+		key:      key,
+		function: middleware,
+	})
+}
+
+// Post processes the provided action by passing it through the middlewares,
+// then executing its associated handlers.
+func (m *actionManager) Post(ctx Context, a Action) {
+	runActionMiddlewares(ctx, m.middlewares, a, func(a Action) {
+		if m.broadcasts[a.Name] && !a.broadcasted {
+			ctx.broadcastAction(ctx, a)
+		}
+		m.post(ctx, a)
+	})
+}
+
+func (m *actionManager) post(ctx Context, a Action) {
+	type sourceHandlers struct {
+		middlewares []ActionMiddleware
+		handlers    []actionHandler
+	}
+
+	m.mutex.Lock()
+	var sources []UI
+	handlersBySource := make(map[UI]*sourceHandlers)
 	for key, handler := range m.handlers[a.Name] {
 		source := handler.Source
 		if !source.Mounted() {
 			delete(m.handlers[a.Name], key)
 			continue
 		}
-		ctx.sourceElement = source
 
-		function := handler.Function
-		if handler.Async {
-			ctx.Async(func() {
-				function(ctx, a)
-			})
-			continue
+		h, ok := handlersBySource[source]
+		if !ok {
+			h = &sourceHandlers{}
+			for _, mw := range m.componentMiddlewares[source] {
+				h.middlewares = append(h.middlewares, mw.function)
+			}
+			handlersBySource[source] = h
+			sources = append(sources, source)
 		}
+		h.handlers = append(h.handlers, handler)
+	}
+	m.mutex.Unlock()
 
-		ctx.Dispatch(func(ctx Context) {
-			function(ctx, a)
+	for _, source := range sources {
+		h := handlersBySource[source]
+		sctx := ctx
+		sctx.sourceElement = source
+
+		runActionMiddlewares(sctx, h.middlewares, a, func(a Action) {
+			for _, handler := range h.handlers {
+				function := handler.Function
+				async := handler.Async
+				execute := func(next func() Action, drop func()) {
+					if async {
+						sctx.Async(func() {
+							function(sctx, next())
+						})
+						return
+					}
+
+					sctx.dispatchOrDrop(func(ctx Context) {
+						function(ctx, next())
+					}, drop)
+				}
+
+				if handler.Limiter == nil {
+					execute(func() Action { return a }, nil)
+					continue
+				}
+				handler.Limiter.post(a, execute)
+			}
 		})
 	}
 }
 
+func runActionMiddlewares(ctx Context, middlewares []ActionMiddleware, a Action, handle func(Action)) {
+	if len(middlewares) == 0 {
+		handle(a)
+		return
+	}
+
+	middlewares[0](ctx, a, func(a Action) {
+		runActionMiddlewares(ctx, middlewares[1:], a, handle)
+	})
+}
+
 // Cleanup removes handlers corresponding to unmounted sources.
 func (m *actionManager) Cleanup() {
 	m.mutex.Lock()
@@ -109,8 +437,123 @@ func (m *actionManager) Cleanup() {
 			delete(m.handlers, action)
 		}
 	}
+
+	for action, handlers := range m.requestHandlers {
+		for key, handler := range handlers {
+			if !handler.Source.Mounted() {
+				delete(handlers, key)
+			}
+		}
+
+		if len(handlers) == 0 {
+			delete(m.requestHandlers, action)
+		}
+	}
+
+	for source := range m.componentMiddlewares {
+		if !source.Mounted() {
+			delete(m.componentMiddlewares, source)
+		}
+	}
 }
 
-func actionHandlerKey(source UI, handler ActionHandler) string {
+func actionHandlerKey(source UI, handler any) string {
 	return fmt.Sprintf("/%T/%p/%p", source, source, handler)
 }
+
+// actionLimiter enforces an action policy on the executions of a handler.
+type actionLimiter struct {
+	policy ActionPolicy
+
+	mutex         sync.Mutex
+	pending       int
+	latest        Action
+	debounceTimer *time.Timer
+	throttleTimer *time.Timer
+	throttleEnd   time.Time
+}
+
+// post schedules the handling of the given action according to the policy.
+// The execute function schedules a handler execution, which gets the action
+// to handle by calling next, or calls drop when the execution is discarded.
+func (l *actionLimiter) post(a Action, execute func(next func() Action, drop func())) {
+	l.mutex.Lock()
+	l.latest = a
+
+	if d := l.policy.Debounce; d > 0 {
+		if l.debounceTimer != nil {
+			l.debounceTimer.Stop()
+		}
+		l.debounceTimer = time.AfterFunc(d, func() {
+			l.mutex.Lock()
+			run := l.throttle(execute)
+			l.mutex.Unlock()
+			run()
+		})
+		l.mutex.Unlock()
+		return
+	}
+
+	run := l.throttle(execute)
+	l.mutex.Unlock()
+	run()
+}
+
+// throttle returns a function that launches the handler execution when it is
+// not throttled. Otherwise, the execution is launched when the throttle
+// duration ends.
+func (l *actionLimiter) throttle(execute func(next func() Action, drop func())) func() {
+	d := l.policy.Throttle
+	if d <= 0 {
+		return l.schedule(l.latest, execute)
+	}
+
+	if l.throttleTimer != nil {
+		return func() {}
+	}
+
+	now := time.Now()
+	if !now.Before(l.throttleEnd) {
+		l.throttleEnd = now.Add(d)
+		return l.schedule(l.latest, execute)
+	}
+
+	l.throttleTimer = time.AfterFunc(l.throttleEnd.Sub(now), func() {
+		l.mutex.Lock()
+		l.throttleTimer = nil
+		l.throttleEnd = time.Now().Add(d)
+		run := l.schedule(l.latest, execute)
+		l.mutex.Unlock()
+		run()
+	})
+	return func() {}
+}
+
+// schedule returns a function that launches the handler execution with the
+// given action, unless the execution is coalesced or the queue is full.
+func (l *actionLimiter) schedule(a Action, execute func(next func() Action, drop func())) func() {
+	if l.policy.Coalesce && l.pending > 0 {
+		return func() {}
+	}
+	if max := l.policy.MaxQueueLength; max > 0 && l.pending >= max {
+		return func() {}
+	}
+
+	l.pending++
+	return func() {
+		execute(func() Action {
+			l.mutex.Lock()
+			defer l.mutex.Unlock()
+
+			l.pending--
+			if l.policy.Coalesce {
+				return l.latest
+			}
+			return a
+		}, func() {
+			l.mutex.Lock()
+			l.pending--
+			l.mutex.Unlock()
+		})
+	}
+}
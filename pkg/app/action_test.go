@@ -2,7 +2,9 @@ package app
 
 import (
 	"testing"
+	"time"
 
+	"github.com/maxence-charriere/go-app/v10/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -11,6 +13,31 @@ func TestHandle(t *testing.T) {
 	require.Len(t, actionHandlers, 1)
 }
 
+func TestHandleWithPolicy(t *testing.T) {
+	defer func() {
+		delete(actionHandlers, "/test/policy")
+		delete(actionPolicies, "/test/policy")
+	}()
+
+	HandleWithPolicy("/test/policy", func(Context, Action) {}, ActionPolicy{Coalesce: true})
+	require.Contains(t, actionHandlers, "/test/policy")
+	require.True(t, actionPolicies["/test/policy"].Coalesce)
+}
+
+func TestHandleRequest(t *testing.T) {
+	HandleRequest("/test", func(Context, Action) (any, error) { return nil, nil })
+	require.Len(t, requestHandlers, 1)
+}
+
+func TestUseActionMiddleware(t *testing.T) {
+	defer func() {
+		actionMiddlewares = nil
+	}()
+
+	UseActionMiddleware(func(Context, Action, func(Action)) {})
+	require.Len(t, actionMiddlewares, 1)
+}
+
 func TestActionManagerHandle(t *testing.T) {
 	var m actionManager
 
@@ -91,6 +118,435 @@ func TestActionManagerPost(t *testing.T) {
 	})
 }
 
+func TestActionManagerPostWithMiddlewares(t *testing.T) {
+	mount := func(t *testing.T) (Context, UI, UI) {
+		var nm nodeManager
+		ctx := makeTestContext()
+
+		source, err := nm.Mount(ctx, 1, Div())
+		require.NoError(t, err)
+		other, err := nm.Mount(ctx, 1, Div())
+		require.NoError(t, err)
+		return nm.context(ctx, source), source, other
+	}
+
+	t.Run("middleware transforms action", func(t *testing.T) {
+		ctx, source, _ := mount(t)
+		var am actionManager
+		var names []string
+		am.middlewares = []ActionMiddleware{
+			func(ctx Context, a Action, next func(Action)) {
+				names = append(names, a.Name)
+				a.Value = 42
+				next(a)
+			},
+		}
+
+		var value any
+		am.Handle("test", source, false, func(ctx Context, a Action) {
+			value = a.Value
+		})
+
+		am.Post(ctx, Action{Name: "test"})
+		require.Equal(t, []string{"test"}, names)
+		require.Equal(t, 42, value)
+	})
+
+	t.Run("middleware drops action", func(t *testing.T) {
+		ctx, source, _ := mount(t)
+		var am actionManager
+		am.middlewares = []ActionMiddleware{
+			func(ctx Context, a Action, next func(Action)) {},
+		}
+
+		handlerCalled := false
+		am.Handle("test", source, false, func(ctx Context, a Action) {
+			handlerCalled = true
+		})
+
+		am.Post(ctx, Action{Name: "test"})
+		require.False(t, handlerCalled)
+	})
+
+	t.Run("middleware delays action", func(t *testing.T) {
+		ctx, source, _ := mount(t)
+		var am actionManager
+		var delayed []func()
+		am.middlewares = []ActionMiddleware{
+			func(ctx Context, a Action, next func(Action)) {
+				delayed = append(delayed, func() { next(a) })
+			},
+		}
+
+		handlerCalled := false
+		am.Handle("test", source, false, func(ctx Context, a Action) {
+			handlerCalled = true
+		})
+
+		am.Post(ctx, Action{Name: "test"})
+		require.False(t, handlerCalled)
+		require.Len(t, delayed, 1)
+
+		delayed[0]()
+		require.True(t, handlerCalled)
+	})
+
+	t.Run("middlewares are called in order", func(t *testing.T) {
+		ctx, source, _ := mount(t)
+		var am actionManager
+		var calls []string
+		am.middlewares = []ActionMiddleware{
+			func(ctx Context, a Action, next func(Action)) {
+				calls = append(calls, "global")
+				next(a)
+			},
+		}
+		am.Use(source, func(ctx Context, a Action, next func(Action)) {
+			calls = append(calls, "component")
+			next(a)
+		})
+
+		am.Handle("test", source, false, func(ctx Context, a Action) {
+			calls = append(calls, "handler")
+		})
+
+		am.Post(ctx, Action{Name: "test"})
+		require.Equal(t, []string{"global", "component", "handler"}, calls)
+	})
+
+	t.Run("component middleware only intercepts component actions", func(t *testing.T) {
+		ctx, source, other := mount(t)
+		var am actionManager
+		am.Use(source, func(ctx Context, a Action, next func(Action)) {})
+
+		sourceCalled := false
+		am.Handle("test", source, false, func(ctx Context, a Action) {
+			sourceCalled = true
+		})
+
+		otherCalled := false
+		am.Handle("test", other, false, func(ctx Context, a Action) {
+			otherCalled = true
+		})
+
+		am.Post(ctx, Action{Name: "test"})
+		require.False(t, sourceCalled)
+		require.True(t, otherCalled)
+	})
+
+	t.Run("component middleware is registered once", func(t *testing.T) {
+		_, source, _ := mount(t)
+		var am actionManager
+		mw := func(ctx Context, a Action, next func(Action)) {}
+
+		am.Use(source, mw)
+		am.Use(source, mw)
+		require.Len(t, am.componentMiddlewares[source], 1)
+	})
+
+	t.Run("delayed action is handled with its source", func(t *testing.T) {
+		ctx, source, other := mount(t)
+		var am actionManager
+		var delayed []func()
+		delay := func(ctx Context, a Action, next func(Action)) {
+			delayed = append(delayed, func() { next(a) })
+		}
+		am.Use(source, delay)
+		am.Use(other, delay)
+
+		var sources []UI
+		handler := func(ctx Context, a Action) {
+			sources = append(sources, ctx.Src())
+		}
+		am.Handle("test", source, false, handler)
+		am.Handle("test", other, false, handler)
+
+		am.Post(ctx, Action{Name: "test"})
+		require.Len(t, delayed, 2)
+		for _, f := range delayed {
+			f()
+		}
+		require.Len(t, sources, 2)
+		require.NotSame(t, sources[0], sources[1])
+		for _, s := range sources {
+			require.True(t, s == source || s == other)
+		}
+	})
+}
+
+func TestActionManagerRequest(t *testing.T) {
+	mount := func(t *testing.T) (Context, UI) {
+		var nm nodeManager
+		ctx := makeTestContext()
+
+		source, err := nm.Mount(ctx, 1, Div())
+		require.NoError(t, err)
+		return nm.context(ctx, source), source
+	}
+
+	request := func(am *actionManager, ctx Context, a Action, timeout time.Duration) (any, error) {
+		type reply struct {
+			value any
+			err   error
+		}
+
+		replies := make(chan reply, 1)
+		am.Request(ctx, a, timeout, func(v any, err error) {
+			replies <- reply{value: v, err: err}
+		})
+		r := <-replies
+		return r.value, r.err
+	}
+
+	t.Run("request is replied", func(t *testing.T) {
+		ctx, source := mount(t)
+		var am actionManager
+		am.HandleRequest("test", source, false, func(ctx Context, a Action) (any, error) {
+			return a.Value.(int) * 2, nil
+		})
+
+		v, err := request(&am, ctx, Action{Name: "test", Value: 21}, 0)
+		require.NoError(t, err)
+		require.Equal(t, 42, v)
+	})
+
+	t.Run("request is replied asynchronously", func(t *testing.T) {
+		ctx, source := mount(t)
+		var am actionManager
+		am.HandleRequest("test", source, true, func(ctx Context, a Action) (any, error) {
+			return "hello", nil
+		})
+
+		v, err := request(&am, ctx, Action{Name: "test"}, time.Second)
+		require.NoError(t, err)
+		require.Equal(t, "hello", v)
+	})
+
+	t.Run("request handler error is replied", func(t *testing.T) {
+		ctx, source := mount(t)
+		var am actionManager
+		am.HandleRequest("test", source, false, func(ctx Context, a Action) (any, error) {
+			return nil, errors.New("test")
+		})
+
+		_, err := request(&am, ctx, Action{Name: "test"}, 0)
+		require.Error(t, err)
+	})
+
+	t.Run("request without handler returns an error", func(t *testing.T) {
+		ctx, _ := mount(t)
+		var am actionManager
+
+		_, err := request(&am, ctx, Action{Name: "test"}, 0)
+		require.True(t, errors.Is(err, ErrNoRequestHandler))
+	})
+
+	t.Run("request handled by a dismounted source returns an error", func(t *testing.T) {
+		e := newTestEngine()
+		hello := &hello{}
+		e.Load(hello)
+		ctx := e.nodes.context(e.baseContext(), hello)
+
+		handlerCalled := false
+		e.actions.HandleRequest("test", hello, false, func(ctx Context, a Action) (any, error) {
+			handlerCalled = true
+			return nil, nil
+		})
+
+		var err error
+		replied := false
+		e.actions.Request(ctx, Action{Name: "test"}, 0, func(v any, e error) {
+			replied = true
+			err = e
+		})
+		e.nodes.Dismount(hello)
+		e.ConsumeAll()
+		require.True(t, replied)
+		require.False(t, handlerCalled)
+		require.True(t, errors.Is(err, ErrNoRequestHandler))
+	})
+
+	t.Run("request with multiple handlers returns an error", func(t *testing.T) {
+		ctx, source := mount(t)
+		var am actionManager
+
+		handlerCalled := false
+		handler := func(ctx Context, a Action) (any, error) {
+			handlerCalled = true
+			return nil, nil
+		}
+		var nm nodeManager
+		other, err := nm.Mount(ctx, 1, Div())
+		require.NoError(t, err)
+
+		am.HandleRequest("test", source, false, handler)
+		am.HandleRequest("test", other, false, handler)
+
+		_, err = request(&am, ctx, Action{Name: "test"}, 0)
+		require.True(t, errors.Is(err, ErrMultipleRequestHandlers))
+		require.False(t, handlerCalled)
+	})
+
+	t.Run("request not replied in time returns an error", func(t *testing.T) {
+		ctx, source := mount(t)
+		var am actionManager
+		am.middlewares = []ActionMiddleware{
+			func(ctx Context, a Action, next func(Action)) {},
+		}
+		am.HandleRequest("test", source, false, func(ctx Context, a Action) (any, error) {
+			return nil, nil
+		})
+
+		_, err := request(&am, ctx, Action{Name: "test"}, time.Millisecond)
+		require.True(t, errors.Is(err, ErrRequestTimeout))
+	})
+
+	t.Run("request is replied through context", func(t *testing.T) {
+		ctx, source := mount(t)
+		var am actionManager
+		ctx.request = am.Request
+		am.HandleRequest("test", source, false, func(ctx Context, a Action) (any, error) {
+			return a.Tags.Get("hello"), nil
+		})
+
+		v, err := ctx.RequestAndWait("test", nil, time.Second, T("hello", "world"))
+		require.NoError(t, err)
+		require.Equal(t, "world", v)
+
+		ctx.Request("test", nil, time.Second, func(ctx Context, v any, err error) {
+			require.NoError(t, err)
+			require.Equal(t, "world", v)
+		}, T("hello", "world"))
+	})
+}
+
+func TestActionManagerPostWithPolicy(t *testing.T) {
+	setup := func(t *testing.T, p ActionPolicy) (*engineX, Context, *[]any) {
+		e := newTestEngine()
+		hello := &hello{}
+		e.Load(hello)
+		ctx := e.nodes.context(e.baseContext(), hello)
+
+		var values []any
+		ctx.HandleWithPolicy("test", func(ctx Context, a Action) {
+			values = append(values, a.Value)
+		}, p)
+		return e, ctx, &values
+	}
+
+	t.Run("coalesced actions are handled once with the latest action", func(t *testing.T) {
+		e, ctx, values := setup(t, ActionPolicy{Coalesce: true})
+		ctx.NewActionWithValue("test", 1)
+		ctx.NewActionWithValue("test", 2)
+		ctx.NewActionWithValue("test", 3)
+		e.ConsumeAll()
+		require.Equal(t, []any{3}, *values)
+
+		ctx.NewActionWithValue("test", 4)
+		e.ConsumeAll()
+		require.Equal(t, []any{3, 4}, *values)
+	})
+
+	t.Run("actions exceeding max queue length are dropped", func(t *testing.T) {
+		e, ctx, values := setup(t, ActionPolicy{MaxQueueLength: 2})
+		ctx.NewActionWithValue("test", 1)
+		ctx.NewActionWithValue("test", 2)
+		ctx.NewActionWithValue("test", 3)
+		e.ConsumeAll()
+		require.Equal(t, []any{1, 2}, *values)
+	})
+
+	t.Run("debounced actions are handled once with the latest action", func(t *testing.T) {
+		e, ctx, values := setup(t, ActionPolicy{Debounce: 10 * time.Millisecond})
+		ctx.NewActionWithValue("test", 1)
+		ctx.NewActionWithValue("test", 2)
+		ctx.NewActionWithValue("test", 3)
+		e.ConsumeAll()
+		require.Empty(t, *values)
+
+		require.Eventually(t, func() bool {
+			e.ConsumeAll()
+			return len(*values) != 0
+		}, time.Second, time.Millisecond)
+		require.Equal(t, []any{3}, *values)
+	})
+
+	t.Run("throttled actions are handled at most once per duration", func(t *testing.T) {
+		e, ctx, values := setup(t, ActionPolicy{Throttle: 20 * time.Millisecond})
+		ctx.NewActionWithValue("test", 1)
+		ctx.NewActionWithValue("test", 2)
+		ctx.NewActionWithValue("test", 3)
+		e.ConsumeAll()
+		require.Equal(t, []any{1}, *values)
+
+		require.Eventually(t, func() bool {
+			e.ConsumeAll()
+			return len(*values) == 2
+		}, time.Second, time.Millisecond)
+		require.Equal(t, []any{1, 3}, *values)
+	})
+
+	t.Run("debounced actions are handled with the source of each handler", func(t *testing.T) {
+		e := newTestEngine()
+		policy := ActionPolicy{Debounce: 10 * time.Millisecond}
+
+		var sources []UI
+		var mounted []UI
+		for i := 0; i < 2; i++ {
+			div, err := e.nodes.Mount(e.baseContext(), 1, Div())
+			require.NoError(t, err)
+			mounted = append(mounted, div)
+
+			ctx := e.nodes.context(e.baseContext(), div)
+			ctx.HandleWithPolicy("test", func(ctx Context, a Action) {
+				sources = append(sources, ctx.Src())
+			}, policy)
+		}
+
+		e.nodes.context(e.baseContext(), mounted[0]).NewAction("test")
+		require.Eventually(t, func() bool {
+			e.ConsumeAll()
+			return len(sources) == 2
+		}, time.Second, time.Millisecond)
+		require.NotSame(t, sources[0], sources[1])
+		for _, s := range sources {
+			require.True(t, s == mounted[0] || s == mounted[1])
+		}
+	})
+
+	t.Run("dropped handler execution is no longer pending", func(t *testing.T) {
+		e, ctx, values := setup(t, ActionPolicy{Coalesce: true})
+		var limiter *actionLimiter
+		for _, h := range e.actions.handlers["test"] {
+			limiter = h.Limiter
+		}
+		require.NotNil(t, limiter)
+
+		ctx.NewActionWithValue("test", 1)
+		e.nodes.Dismount(ctx.Src())
+		e.ConsumeAll()
+		require.Empty(t, *values)
+		require.Zero(t, limiter.pending)
+	})
+
+	t.Run("limiter is kept when handler is registered again", func(t *testing.T) {
+		var am actionManager
+		source := Div()
+		handler := func(ctx Context, a Action) {}
+		key := actionHandlerKey(source, handler)
+
+		am.HandleWithPolicy("test", source, false, handler, ActionPolicy{Coalesce: true})
+		limiter := am.handlers["test"][key].Limiter
+		require.NotNil(t, limiter)
+
+		am.HandleWithPolicy("test", source, false, handler, ActionPolicy{Coalesce: true})
+		require.Same(t, limiter, am.handlers["test"][key].Limiter)
+
+		am.Handle("test", source, false, handler)
+		require.Nil(t, am.handlers["test"][key].Limiter)
+	})
+}
+
 func TestActionManagerCleanup(t *testing.T) {
 	var m actionManager
 
@@ -98,6 +554,10 @@ func TestActionManagerCleanup(t *testing.T) {
 	require.Len(t, m.handlers, 1)
 	require.Len(t, m.handlers["test"], 1)
 
+	m.Use(Div(), func(ctx Context, a Action, next func(Action)) {})
+	require.Len(t, m.componentMiddlewares, 1)
+
 	m.Cleanup()
 	require.Empty(t, m.handlers)
+	require.Empty(t, m.componentMiddlewares)
 }
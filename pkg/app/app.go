@@ -106,6 +106,8 @@ func RunWhenOnBrowser() {
 		&originPage,
 		actionHandlers,
 	)
+	engine.hydrate = Getenv("GOAPP_HYDRATE") == "true"
+	engine.restoreTransferredStates()
 
 	engine.Navigate(window.URL(), false)
 	engine.Start(120)
@@ -125,11 +127,27 @@ func displayLoadError(err any) {
 // component. When a user navigates to the specified path, the function
 // newComponent is invoked to create and mount the associated component.
 //
+// The path can contain parameters enclosed in braces, whose values are
+// retrieved with Context.Param:
+//   - "{id}" matches any non-empty path segment.
+//   - "{slug:[a-z-]+}" matches a path segment that satisfies the given regular
+//     expression.
+//   - "{path...}" matches the rest of the path and must be the last segment.
+//
+// Static paths take precedence over paths with parameters, which take
+// precedence over routes defined with RouteWithRegexp. Among paths with
+// parameters, static segments take precedence over constrained parameters,
+// which take precedence over unconstrained parameters and rest parameters.
+//
 // Example:
 //
 //	Route("/home", func() Composer {
 //	    return NewHomeComponent()
 //	})
+//
+//	Route("/users/{id}/posts/{slug:[a-z-]+}", func() Composer {
+//	    return NewPostComponent()
+//	})
 func Route(path string, newComponent func() Composer) {
 	routes.route(path, newComponent)
 }
@@ -148,6 +166,40 @@ func RouteWithRegexp(pattern string, newComponent func() Composer) {
 	routes.routeWithRegexp(pattern, newComponent)
 }
 
+// RouteLayout associates a path prefix with a function that generates a new
+// Layout component. The layout wraps the components of the routes whose path
+// is the prefix or starts with the prefix followed by a slash. Layouts with
+// nested prefixes wrap each other, the shortest prefix being the outermost.
+//
+// A layout stays mounted while navigating between the routes it wraps: the
+// newly routed component is swapped into its outlet instead of rebuilding the
+// whole page.
+//
+// Example:
+//
+//	RouteLayout("/docs", func() Layout {
+//	    return &docsLayout{}
+//	})
+//
+//	type docsLayout struct {
+//	    Compo
+//	    content UI
+//	}
+//
+//	func (l *docsLayout) SetOutlet(v UI) {
+//	    l.content = v
+//	}
+//
+//	func (l *docsLayout) Render() UI {
+//	    return Div().Body(
+//	        Nav().Text("Menu"),
+//	        Main().Body(l.content),
+//	    )
+//	}
+func RouteLayout(prefix string, newLayout func() Layout) {
+	routes.routeLayout(prefix, newLayout)
+}
+
 // NewZeroComponentFactory returns a function that, when invoked, creates and
 // returns a new instance of the same type as the provided component. The new
 // instance is initialized with zero values for all its fields.
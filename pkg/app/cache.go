@@ -1,7 +1,11 @@
 package app
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 )
 
 type cacheItem struct {
@@ -45,3 +49,114 @@ func (c *memoryCache) Get(path string) (cacheItem, bool) {
 	c.mu.Unlock()
 	return i, ok
 }
+
+// PageCachePolicy describes how the pre-rendered pages of a route are cached
+// by a Handler.
+type PageCachePolicy struct {
+	// TTL is the duration while a pre-rendered page is served from the cache.
+	// Pages are not cached when TTL is not positive.
+	TTL time.Duration
+
+	// VaryHeaders lists the request headers whose values result in distinct
+	// cached pages.
+	VaryHeaders []string
+
+	// VaryCookies lists the request cookies whose values result in distinct
+	// cached pages.
+	VaryCookies []string
+
+	// VaryQuery lists the URL query parameters whose values result in distinct
+	// cached pages. Other query parameters are ignored.
+	VaryQuery []string
+}
+
+func (p PageCachePolicy) key(r *http.Request) string {
+	var b strings.Builder
+	b.WriteString(r.URL.Path)
+
+	query := r.URL.Query()
+	for _, name := range p.VaryQuery {
+		b.WriteString("\nquery:")
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(strings.Join(query[name], ","))
+	}
+
+	for _, name := range p.VaryHeaders {
+		b.WriteString("\nheader:")
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(strings.Join(r.Header.Values(name), ","))
+	}
+
+	for _, name := range p.VaryCookies {
+		b.WriteString("\ncookie:")
+		b.WriteString(name)
+		b.WriteByte('=')
+		if cookie, err := r.Cookie(name); err == nil {
+			b.WriteString(cookie.Value)
+		}
+	}
+
+	return b.String()
+}
+
+type cachedPage struct {
+	status    int
+	header    http.Header
+	body      []byte
+	nonce     string
+	expiresAt time.Time
+}
+
+func (p cachedPage) Size() int {
+	return len(p.body)
+}
+
+// pageCacheGenerations tracks how many times cached pages were invalidated.
+// Generations are part of the page cache keys so that invalidated pages are
+// no longer found, and are eventually evicted by the cache.
+type pageCacheGenerations struct {
+	mu     sync.Mutex
+	all    uint64
+	routes map[string]uint64
+	paths  map[string]uint64
+}
+
+func (g *pageCacheGenerations) key(pattern, path string) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return strconv.FormatUint(g.all, 10) + "." +
+		strconv.FormatUint(g.routes[pattern], 10) + "." +
+		strconv.FormatUint(g.paths[path], 10)
+}
+
+func (g *pageCacheGenerations) invalidateAll() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.all++
+	g.routes = nil
+	g.paths = nil
+}
+
+func (g *pageCacheGenerations) invalidateRoute(pattern string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.routes == nil {
+		g.routes = make(map[string]uint64)
+	}
+	g.routes[pattern]++
+}
+
+func (g *pageCacheGenerations) invalidatePath(path string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.paths == nil {
+		g.paths = make(map[string]uint64)
+	}
+	g.paths[path]++
+}
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -25,3 +27,48 @@ func TestMemoryCache(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, i, ic)
 }
+
+func TestPageCachePolicyKey(t *testing.T) {
+	policy := PageCachePolicy{
+		VaryHeaders: []string{"Accept-Language"},
+		VaryCookies: []string{"theme"},
+		VaryQuery:   []string{"page"},
+	}
+
+	newRequest := func(target, lang, theme string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		if lang != "" {
+			r.Header.Set("Accept-Language", lang)
+		}
+		if theme != "" {
+			r.AddCookie(&http.Cookie{Name: "theme", Value: theme})
+		}
+		return r
+	}
+
+	key := policy.key(newRequest("/hello?page=1", "en", "dark"))
+	require.Equal(t, key, policy.key(newRequest("/hello?page=1&utm=ad", "en", "dark")))
+	require.NotEqual(t, key, policy.key(newRequest("/bye?page=1", "en", "dark")))
+	require.NotEqual(t, key, policy.key(newRequest("/hello?page=2", "en", "dark")))
+	require.NotEqual(t, key, policy.key(newRequest("/hello?page=1", "fr", "dark")))
+	require.NotEqual(t, key, policy.key(newRequest("/hello?page=1", "en", "light")))
+}
+
+func TestPageCacheGenerations(t *testing.T) {
+	var g pageCacheGenerations
+
+	key := g.key("/users/{id}", "/users/42")
+	require.Equal(t, key, g.key("/users/{id}", "/users/42"))
+
+	g.invalidatePath("/users/42")
+	require.NotEqual(t, key, g.key("/users/{id}", "/users/42"))
+	require.Equal(t, g.key("/users/{id}", "/users/21"), g.key("/users/{id}", "/users/21"))
+
+	key = g.key("/users/{id}", "/users/21")
+	g.invalidateRoute("/users/{id}")
+	require.NotEqual(t, key, g.key("/users/{id}", "/users/21"))
+
+	key = g.key("/", "/")
+	g.invalidateAll()
+	require.NotEqual(t, key, g.key("/", "/"))
+}
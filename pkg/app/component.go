@@ -32,11 +32,15 @@ type Composer interface {
 	Render() UI
 
 	setRef(Composer) Composer
+	key() any
+	setKey(any)
 	depth() uint
 	setDepth(uint) Composer
 	parent() UI
 	root() UI
 	setRoot(UI) Composer
+	fallback() UI
+	setFallback(UI)
 }
 
 // Initializer describes a component that requires initialization
@@ -92,6 +96,61 @@ type Navigator interface {
 	OnNav(Context)
 }
 
+// Layout describes components that wrap the components of the routes under a
+// path prefix, such as a shell with a menu and a header. A layout defined with
+// RouteLayout stays mounted while navigating between the routes it wraps,
+// preserving its state, and displays the routed component where it renders the
+// UI element given to SetOutlet.
+type Layout interface {
+	Composer
+
+	// SetOutlet sets the UI element to display within the layout: the routed
+	// component, or the nested layout that wraps it. It is called before the
+	// layout is mounted, then on each navigation between the routes it wraps,
+	// right before the layout is updated.
+	// This method is always executed within the UI goroutine.
+	SetOutlet(UI)
+}
+
+// BeforeLeaver describes components that need to intercept navigations away
+// from the page they are part of, for example to ask for confirmation before
+// discarding unsaved changes.
+type BeforeLeaver interface {
+	// OnBeforeLeave is invoked on mounted components before navigating to
+	// another page. Calling Cancel or Redirect on the given navigation stops
+	// it, and the current page stays displayed.
+	// This function is always executed within the UI goroutine.
+	OnBeforeLeave(Context, *Navigation)
+}
+
+// BeforeEnterer describes components that need to intercept navigations
+// targeting them, for example to redirect unauthenticated users.
+type BeforeEnterer interface {
+	// OnBeforeEnter is invoked on the component created for the destination
+	// page, before it is mounted. Calling Cancel or Redirect on the given
+	// navigation stops it. When pre-rendering, it is called on the server.
+	// This function is always executed within the UI goroutine.
+	OnBeforeEnter(Context, *Navigation)
+}
+
+// Loader describes components that need to load data before being displayed
+// as the target of a navigation.
+type Loader interface {
+	// OnLoad is invoked on the component created for the destination page,
+	// before it is mounted, and delays the navigation until it returns. Loaded
+	// data should be stored in the component fields, which makes it available
+	// in OnPreRender, OnMount, and OnNav.
+	//
+	// Returning an error, calling Cancel, or calling Redirect on the given
+	// navigation stops it. When pre-rendering, it is called on the server and
+	// the page is rendered once it returns.
+	//
+	// This function is executed in a separate goroutine. The component being
+	// not mounted yet, Context.Dispatch calls made with the given context are
+	// ignored.
+	OnLoad(Context, *Navigation) error
+}
+
 // Updater encapsulates components that require specific behaviors or reactions
 // when one of their exported fields is updated by the closest parent component.
 // Implementing the Updater interface allows components to define responsive
@@ -104,6 +163,25 @@ type Updater interface {
 	OnUpdate(Context)
 }
 
+// ErrorBoundary describes components that catch the failures occurring in
+// their subtree, which includes themselves and their descendants: Render
+// methods that panic or do not return a UI element, and lifecycle methods that
+// panic. A failure is caught by the nearest error boundary, which displays a
+// fallback in place of its content while the rest of the app keeps running.
+//
+// Failures that are not caught by an error boundary stop the app.
+type ErrorBoundary interface {
+	Composer
+
+	// OnError is called when a failure occurs in the subtree of the component.
+	// The given error is tagged with the path of the failing component, which
+	// can be retrieved with errors.Tag(err, "component-path"). The returned UI
+	// element is displayed in place of the component rendering until the
+	// component is dismounted.
+	// This function is always executed within the UI goroutine.
+	OnError(Context, error) UI
+}
+
 // AppUpdater defines components that are alerted when a newer version of the
 // application is downloaded in the background. Implementing this interface
 // allows components to proactively adapt to app updates, ensuring coherence
@@ -147,13 +225,33 @@ type Resizer interface {
 	OnResize(Context)
 }
 
+// Keyed assigns a key to the given component, identifying it among its
+// siblings. When the parent element is updated, keyed children are matched by
+// key instead of by position, which preserves their state when they are
+// inserted, moved, or removed within a list.
+//
+// Keys must be comparable and unique among siblings. HTML elements are keyed
+// with their Key method.
+//
+// Example:
+//
+//	app.Range(rows).Slice(func(i int) app.UI {
+//	    return app.Keyed(rows[i].ID, &row{Data: rows[i]})
+//	})
+func Keyed(k any, c Composer) Composer {
+	c.setKey(k)
+	return c
+}
+
 // Compo serves as the foundational struct for constructing a component. It
 // provides basic methods and fields needed for component management.
 type Compo struct {
 	treeDepth     uint
+	compoKey      any
 	ref           Composer
 	parentElement UI
 	rootElement   UI
+	errorFallback UI
 }
 
 // JSValue retrieves the JavaScript value associated with the component's root.
@@ -212,6 +310,14 @@ func (c *Compo) setRef(v Composer) Composer {
 	return v
 }
 
+func (c *Compo) key() any {
+	return c.compoKey
+}
+
+func (c *Compo) setKey(v any) {
+	c.compoKey = v
+}
+
 func (c *Compo) depth() uint {
 	return c.treeDepth
 }
@@ -238,3 +344,11 @@ func (c *Compo) setRoot(v UI) Composer {
 	c.rootElement = v
 	return c.ref
 }
+
+func (c *Compo) fallback() UI {
+	return c.errorFallback
+}
+
+func (c *Compo) setFallback(v UI) {
+	c.errorFallback = v
+}
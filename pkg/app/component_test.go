@@ -51,6 +51,41 @@ func (h *hello) Render() UI {
 	)
 }
 
+type errorBoundaryCompo struct {
+	Compo
+	Content UI
+
+	err error
+}
+
+func (c *errorBoundaryCompo) OnError(ctx Context, err error) UI {
+	c.err = err
+	return Text("fallback")
+}
+
+func (c *errorBoundaryCompo) Render() UI {
+	return Div().Body(c.Content)
+}
+
+type panicCompo struct {
+	Compo
+	RenderPanic bool
+	NavPanic    bool
+}
+
+func (c *panicCompo) OnNav(ctx Context) {
+	if c.NavPanic {
+		panic("nav failed")
+	}
+}
+
+func (c *panicCompo) Render() UI {
+	if c.RenderPanic {
+		panic("render failed")
+	}
+	return Text("ok")
+}
+
 type foo struct {
 	Compo
 	Bar string
@@ -17,6 +17,9 @@ type Context struct {
 	context.Context
 
 	page                  func() Page
+	routeParam            func(string) string
+	requestHeader         func(string) string
+	requestCookie         func(string) string
 	appUpdatable          bool
 	resolveURL            func(string) string
 	navigate              func(*url.URL, bool)
@@ -24,18 +27,32 @@ type Context struct {
 	sessionStorage        BrowserStorage
 	dispatch              func(func())
 	defere                func(func())
-	async                 func(func())
+	async                 func(UI, func())
 	addComponentUpdate    func(Composer, int)
 	removeComponentUpdate func(Composer)
 	handleAction          func(string, UI, bool, ActionHandler)
+	handleActionPolicy    func(string, UI, bool, ActionHandler, ActionPolicy)
 	postAction            func(Context, Action)
+	useActionMiddleware   func(UI, ActionMiddleware)
+	handleRequest         func(string, UI, bool, RequestHandler)
+	request               func(Context, Action, time.Duration, func(any, error))
 	observeState          func(Context, string, any) Observer
 	getState              func(Context, string, any)
 	setState              func(Context, string, any) State
 	delState              func(Context, string)
+	computeState          func(Context, string, func(Context) any)
+	undoState             func(Context, string)
+	redoState             func(Context, string)
+	canUndoState          func(string) bool
+	canRedoState          func(string) bool
+	groupStateHistory     func(func())
+	updateStates          func(Context, func(*StateTransaction) error) error
+	broadcastAction       func(Context, Action)
 
 	sourceElement        UI
+	componentScope       *componentScope
 	notifyComponentEvent func(Context, UI, any)
+	encodePlaceholder    func(Composer) (UI, bool)
 }
 
 // Src retrieves the linked UI element of the context.
@@ -90,6 +107,29 @@ func (ctx Context) Page() Page {
 	return ctx.page()
 }
 
+// Param returns the value of the named parameter from the route that matched
+// the current page path. Parameters are declared in braces within the path
+// given to Route, such as "id" in "/users/{id}". It returns an empty string
+// when the parameter does not exist.
+func (ctx Context) Param(name string) string {
+	return ctx.routeParam(name)
+}
+
+// RequestHeader returns the value of the given header from the HTTP request
+// of the page being pre-rendered. It returns an empty string in the browser or
+// when the header is not set.
+func (ctx Context) RequestHeader(name string) string {
+	return ctx.requestHeader(name)
+}
+
+// RequestCookie returns the value of the cookie with the given name. When
+// pre-rendering, the cookie comes from the HTTP request of the page. In the
+// browser, it comes from the document cookies, which excludes HTTP-only
+// cookies. It returns an empty string when the cookie is not set.
+func (ctx Context) RequestCookie(name string) string {
+	return ctx.requestCookie(name)
+}
+
 // Reload refreshes the present page.
 func (ctx Context) Reload() {
 	if IsServer {
@@ -140,6 +180,13 @@ func (ctx Context) SessionStorage() BrowserStorage {
 	return ctx.sessionStorage
 }
 
+func (ctx Context) persistedStateStorage() BrowserStorage {
+	if persistedStateStorage != nil && IsClient {
+		return persistedStateStorage
+	}
+	return ctx.LocalStorage()
+}
+
 // Encrypt enciphers a value using AES encryption.
 func (ctx Context) Encrypt(v any) ([]byte, error) {
 	b, err := json.Marshal(v)
@@ -179,8 +226,17 @@ func (ctx Context) Notifications() NotificationService {
 // Dispatch prompts the execution of a function on the UI goroutine,
 // flagging the enclosing component for an update.
 func (ctx Context) Dispatch(v func(Context)) {
+	ctx.dispatchOrDrop(v, nil)
+}
+
+// dispatchOrDrop is like Dispatch, but calls drop when the function is
+// discarded because the source element is no longer mounted.
+func (ctx Context) dispatchOrDrop(v func(Context), drop func()) {
 	ctx.dispatch(func() {
 		if !ctx.sourceElement.Mounted() {
+			if drop != nil {
+				drop()
+			}
 			return
 		}
 
@@ -211,12 +267,12 @@ func (ctx Context) Defer(v func(Context)) {
 // Async initiates a function asynchronously. It enables go-app to monitor
 // goroutines, ensuring they conclude when rendering server-side.
 func (ctx Context) Async(v func()) {
-	ctx.async(v)
+	ctx.async(ctx.sourceElement, v)
 }
 
 // After pauses for a determined span, then triggers a specified function.
 func (ctx Context) After(d time.Duration, f func(Context)) {
-	ctx.async(func() {
+	ctx.async(nil, func() {
 		time.Sleep(d)
 		ctx.Dispatch(f)
 	})
@@ -240,6 +296,61 @@ func (ctx Context) Handle(action string, h ActionHandler) {
 	ctx.handleAction(action, ctx.sourceElement, false, h)
 }
 
+// HandleWithPolicy designates a handler for a particular action, set to run on
+// the UI goroutine, whose executions are limited by the given policy.
+func (ctx Context) HandleWithPolicy(action string, h ActionHandler, p ActionPolicy) {
+	ctx.handleActionPolicy(action, ctx.sourceElement, false, h, p)
+}
+
+// HandleRequest designates a handler that replies to a particular request
+// action, set to run on the UI goroutine.
+func (ctx Context) HandleRequest(action string, h RequestHandler) {
+	ctx.handleRequest(action, ctx.sourceElement, false, h)
+}
+
+// Request posts a request action with the given value and calls the given
+// function on the UI goroutine with the reply of its handler.
+//
+// The reply is an error that wraps ErrNoRequestHandler when the action has no
+// handler, ErrMultipleRequestHandlers when it has several handlers, and
+// ErrRequestTimeout when it is not replied within the timeout. A timeout of 0
+// or less waits indefinitely.
+func (ctx Context) Request(action string, v any, timeout time.Duration, reply func(ctx Context, v any, err error), tags ...Tagger) {
+	ctx.request(ctx, newAction(action, v, tags...), timeout, func(v any, err error) {
+		ctx.Dispatch(func(ctx Context) {
+			reply(ctx, v, err)
+		})
+	})
+}
+
+// RequestAndWait posts a request action with the given value and waits for
+// the reply of its handler. See Request for details about the reply.
+//
+// It must not be called from the UI goroutine, since the handlers set with
+// Context.HandleRequest run on it. Call it from a function launched with
+// Async instead.
+func (ctx Context) RequestAndWait(action string, v any, timeout time.Duration, tags ...Tagger) (any, error) {
+	type reply struct {
+		value any
+		err   error
+	}
+
+	replies := make(chan reply, 1)
+	ctx.request(ctx, newAction(action, v, tags...), timeout, func(v any, err error) {
+		replies <- reply{value: v, err: err}
+	})
+
+	r := <-replies
+	return r.value, r.err
+}
+
+// UseActionMiddleware registers a middleware that intercepts the actions
+// before they reach the handlers set with Handle by the associated UI element.
+// It is removed when the element is dismounted.
+func (ctx Context) UseActionMiddleware(m ActionMiddleware) {
+	ctx.useActionMiddleware(ctx.sourceElement, m)
+}
+
 // NewAction generates a new action for handling.
 func (ctx Context) NewAction(action string, tags ...Tagger) {
 	ctx.NewActionWithValue(action, nil, tags...)
@@ -248,6 +359,10 @@ func (ctx Context) NewAction(action string, tags ...Tagger) {
 
 // NewActionWithValue crafts an action with a given value for processing.
 func (ctx Context) NewActionWithValue(action string, v any, tags ...Tagger) {
+	ctx.postAction(ctx, newAction(action, v, tags...))
+}
+
+func newAction(name string, v any, tags ...Tagger) Action {
 	var tagMap Tags
 	for _, tag := range tags {
 		if tagMap == nil {
@@ -259,11 +374,11 @@ func (ctx Context) NewActionWithValue(action string, v any, tags ...Tagger) {
 		}
 	}
 
-	ctx.postAction(ctx, Action{
-		Name:  action,
+	return Action{
+		Name:  name,
 		Value: v,
 		Tags:  tagMap,
-	})
+	}
 }
 
 // ObserveState establishes an observer for a state, tracking its changes.
@@ -286,6 +401,56 @@ func (ctx Context) DelState(state string) {
 	ctx.delState(ctx, state)
 }
 
+// ComputeState defines a state whose value is computed by the given function
+// from other states. The states retrieved with the Context given to the
+// function are tracked as dependencies: the value is computed again when the
+// state is retrieved after one of them changed, and the state observers are
+// notified of such changes. Dependency cycles are logged as errors.
+//
+// Setting or deleting the state removes its definition.
+func (ctx Context) ComputeState(state string, compute func(Context) any) {
+	ctx.computeState(ctx, state, compute)
+}
+
+// UndoState restores the previous value of a state whose history is enabled
+// with State.WithHistory, and notifies its observers.
+func (ctx Context) UndoState(state string) {
+	ctx.undoState(ctx, state)
+}
+
+// RedoState restores the value of a state that was replaced by UndoState, and
+// notifies its observers. Redoable values are discarded when the state is set.
+func (ctx Context) RedoState(state string) {
+	ctx.redoState(ctx, state)
+}
+
+// CanUndoState reports whether a state has a previous value to restore with
+// UndoState.
+func (ctx Context) CanUndoState(state string) bool {
+	return ctx.canUndoState(state)
+}
+
+// CanRedoState reports whether a state has a value to restore with RedoState.
+func (ctx Context) CanRedoState(state string) bool {
+	return ctx.canRedoState(state)
+}
+
+// GroupStateHistory records the changes made to states with history during the
+// execution of the given function as a single history step shared by all the
+// changed states: UndoState and RedoState restore all of them at once.
+func (ctx Context) GroupStateHistory(f func()) {
+	ctx.groupStateHistory(f)
+}
+
+// UpdateStates calls the given function with a transaction that stages state
+// sets and deletes. The staged changes are applied atomically when the
+// function returns without error, then each affected observer is notified
+// once. The changes are rolled back when the function returns an error, which
+// is then returned.
+func (ctx Context) UpdateStates(f func(tx *StateTransaction) error) error {
+	return ctx.updateStates(ctx, f)
+}
+
 // ResizeContent notifies the children of the associated element that implement
 // the Resizer interface about a resize event. It ensures that components can
 // adjust their size and layout in response to changes. This method is typically
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/maxence-charriere/go-app/v10/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -351,15 +352,28 @@ func TestContextResizeContent(t *testing.T) {
 	e.ConsumeAll()
 }
 
+func TestMakeTestContext(t *testing.T) {
+	ctx := makeTestContext()
+
+	ctx.HandleWithPolicy("test", func(Context, Action) {}, ActionPolicy{Coalesce: true})
+	_, err := ctx.RequestAndWait("test", nil, 0)
+	require.True(t, errors.Is(err, ErrNoRequestHandler))
+
+	ctx.SetState("/test/make-test-context", 42)
+	var v int
+	ctx.GetState("/test/make-test-context", &v)
+	require.Equal(t, 42, v)
+}
+
 func makeTestContext() Context {
 	resolveURL := func(v string) string {
 		return v
 	}
 
 	var page Page
-	url, _ := url.Parse("https://goapp.dev")
+	u, _ := url.Parse("https://goapp.dev")
 	if IsServer {
-		requestPage := makeRequestPage(url, resolveURL)
+		requestPage := makeRequestPage(u, resolveURL)
 		page = &requestPage
 	} else {
 		page = makeBrowserPage(resolveURL)
@@ -375,18 +389,46 @@ func makeTestContext() Context {
 		sessionStorage = newJSStorage("sessionStorage")
 	}
 
+	states := &stateManager{}
+
 	return Context{
 		Context:               context.Background(),
 		page:                  func() Page { return page },
+		routeParam:            func(string) string { return "" },
+		requestHeader:         func(string) string { return "" },
+		requestCookie:         func(string) string { return "" },
 		resolveURL:            resolveURL,
+		navigate:              func(*url.URL, bool) {},
 		localStorage:          localStorage,
 		sessionStorage:        sessionStorage,
 		dispatch:              func(f func()) { f() },
 		defere:                func(f func()) { f() },
-		async:                 func(f func()) { f() },
+		async:                 func(_ UI, f func()) { f() },
 		addComponentUpdate:    func(Composer, int) {},
 		removeComponentUpdate: func(Composer) {},
 		handleAction:          func(string, UI, bool, ActionHandler) {},
+		handleActionPolicy:    func(string, UI, bool, ActionHandler, ActionPolicy) {},
 		postAction:            func(Context, Action) {},
+		useActionMiddleware:   func(UI, ActionMiddleware) {},
+		handleRequest:         func(string, UI, bool, RequestHandler) {},
+		request: func(_ Context, a Action, _ time.Duration, reply func(any, error)) {
+			reply(nil, errors.New("requesting action failed").
+				WithTag("action", a.Name).
+				Wrap(ErrNoRequestHandler))
+		},
+		observeState:      states.Observe,
+		getState:          states.Get,
+		setState:          states.Set,
+		delState:          states.Delete,
+		computeState:      states.Compute,
+		undoState:         states.Undo,
+		redoState:         states.Redo,
+		canUndoState:      states.CanUndo,
+		canRedoState:      states.CanRedo,
+		groupStateHistory: states.GroupHistory,
+		updateStates:      states.Transaction,
+		broadcastAction:   states.BroadcastAction,
+
+		notifyComponentEvent: func(Context, UI, any) {},
 	}
 }
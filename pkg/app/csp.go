@@ -0,0 +1,219 @@
+package app
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"sort"
+	"strings"
+
+	"github.com/maxence-charriere/go-app/v10/pkg/errors"
+)
+
+// ContentSecurityPolicy describes the Content-Security-Policy header sent with
+// the pages served by a Handler.
+type ContentSecurityPolicy struct {
+	// Directives maps policy directives, such as "default-src" or "img-src",
+	// to their sources. No policy is sent when empty.
+	//
+	// The script and style sources, or the default sources when these are not
+	// specified, are completed with a nonce that is generated for each page
+	// and set on the scripts, links and styles rendered by the Handler. Script
+	// sources also get the hashes of app.js, app-worker.js, wasm_exec.js and
+	// of the inline scripts in RawHeaders, and 'wasm-unsafe-eval' to allow the
+	// app to be loaded. Style sources also get the hashes of the inline styles
+	// in RawHeaders.
+	//
+	// Style sources that allow 'unsafe-inline' are not completed with the
+	// nonce nor the hashes, and styles and links are then rendered without
+	// nonce, since browsers ignore 'unsafe-inline' when a nonce or a hash is
+	// present, which would block the inline style attributes of pre-rendered
+	// pages.
+	Directives map[string][]string
+
+	// ReportOnly sends the policy with the
+	// Content-Security-Policy-Report-Only header, which reports violations
+	// without enforcing the policy.
+	ReportOnly bool
+}
+
+func (p ContentSecurityPolicy) enabled() bool {
+	return len(p.Directives) != 0
+}
+
+func (p ContentSecurityPolicy) headerName() string {
+	if p.ReportOnly {
+		return "Content-Security-Policy-Report-Only"
+	}
+	return "Content-Security-Policy"
+}
+
+// styleNonce reports whether the style sources are completed with the nonce.
+// It is false when they allow 'unsafe-inline'.
+func (p ContentSecurityPolicy) styleNonce() bool {
+	return !containsSource(p.styleSources(p.directives()), "'unsafe-inline'")
+}
+
+func (p ContentSecurityPolicy) directives() map[string][]string {
+	directives := make(map[string][]string, len(p.Directives)+2)
+	for name, sources := range p.Directives {
+		directives[strings.ToLower(name)] = sources
+	}
+	return directives
+}
+
+func (p ContentSecurityPolicy) styleSources(directives map[string][]string) []string {
+	if sources, ok := directives["style-src"]; ok {
+		return sources
+	}
+	return directives["default-src"]
+}
+
+// header returns the policy header value that allows the scripts, links and
+// styles with the given nonce, and the scripts and styles with the given
+// hashes.
+func (p ContentSecurityPolicy) header(nonce string, scriptHashes, styleHashes []string) string {
+	directives := p.directives()
+	useStyleNonce := p.styleNonce()
+
+	defaultSources, hasDefaultSources := directives["default-src"]
+	complete := func(name string, sources ...string) {
+		current, ok := directives[name]
+		if !ok && !hasDefaultSources {
+			return
+		}
+		if !ok {
+			current = defaultSources
+		}
+
+		completed := make([]string, 0, len(current)+len(sources))
+		completed = append(completed, current...)
+		for _, s := range sources {
+			if !containsSource(completed, s) {
+				completed = append(completed, s)
+			}
+		}
+		directives[name] = completed
+	}
+
+	nonceSource := "'nonce-" + nonce + "'"
+	scriptSources := []string{nonceSource, "'wasm-unsafe-eval'"}
+	for _, h := range scriptHashes {
+		scriptSources = append(scriptSources, "'"+h+"'")
+	}
+	complete("script-src", scriptSources...)
+	if useStyleNonce {
+		styleSources := []string{nonceSource}
+		for _, h := range styleHashes {
+			styleSources = append(styleSources, "'"+h+"'")
+		}
+		complete("style-src", styleSources...)
+	}
+
+	names := make([]string, 0, len(directives))
+	for name := range directives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(name)
+		for _, s := range directives[name] {
+			b.WriteByte(' ')
+			b.WriteString(s)
+		}
+	}
+	return b.String()
+}
+
+func containsSource(sources []string, s string) bool {
+	for _, source := range sources {
+		if source == s {
+			return true
+		}
+	}
+	return false
+}
+
+// newCSPNonce generates a random nonce to be used in a single response.
+func newCSPNonce() string {
+	b := make([]byte, 18)
+	if _, err := rand.Read(b); err != nil {
+		panic(errors.New("generating content security policy nonce failed").Wrap(err))
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// cspHash returns the hash of the given content, formatted as expected by
+// content security policies and subresource integrity checks.
+func cspHash(content []byte) string {
+	sum := sha512.Sum384(content)
+	return "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// inlineHashes returns the hashes of the content of the elements with the
+// given tag, such as script or style, within the given raw HTML. Elements with
+// a src attribute are ignored.
+func inlineHashes(rawHTML, tag string) []string {
+	var hashes []string
+	lower := strings.ToLower(rawHTML)
+
+	for {
+		start := strings.Index(lower, "<"+tag)
+		if start < 0 {
+			return hashes
+		}
+		tagEnd := strings.IndexByte(lower[start:], '>')
+		if tagEnd < 0 {
+			return hashes
+		}
+		tagEnd += start
+		end := strings.Index(lower[tagEnd:], "</"+tag)
+		if end < 0 {
+			return hashes
+		}
+		end += tagEnd
+
+		external := false
+		for _, attr := range strings.Fields(lower[start:tagEnd]) {
+			if strings.HasPrefix(attr, "src=") {
+				external = true
+				break
+			}
+		}
+		if !external {
+			hashes = append(hashes, cspHash([]byte(rawHTML[tagEnd+1:end])))
+		}
+
+		rawHTML = rawHTML[end:]
+		lower = lower[end:]
+	}
+}
+
+// setNonce sets the given nonce on the scripts within the given element, and
+// on the links and styles when styles is true.
+func setNonce(v UI, nonce string, styles bool) {
+	element, ok := v.(HTML)
+	if !ok {
+		return
+	}
+
+	switch tag := element.Tag(); {
+	case tag == "script",
+		styles && (tag == "link" || tag == "style"):
+		attrs := element.attrs()
+		if attrs == nil {
+			attrs = make(attributes)
+		}
+		attrs.Set("nonce", nonce)
+		element.setAttrs(attrs)
+	}
+
+	for _, child := range element.body() {
+		setNonce(child, nonce, styles)
+	}
+}
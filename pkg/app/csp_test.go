@@ -0,0 +1,154 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentSecurityPolicyHeader(t *testing.T) {
+	utests := []struct {
+		scenario   string
+		directives map[string][]string
+		expected   string
+	}{
+		{
+			scenario: "script and style sources are completed",
+			directives: map[string][]string{
+				"script-src": {"'self'"},
+				"style-src":  {"'self'", "https://fonts.googleapis.com"},
+				"img-src":    {"*"},
+			},
+			expected: "img-src *; " +
+				"script-src 'self' 'nonce-n0nce' 'wasm-unsafe-eval' 'sha384-h4sh'; " +
+				"style-src 'self' https://fonts.googleapis.com 'nonce-n0nce' 'sha384-st7le'",
+		},
+		{
+			scenario: "default sources are completed",
+			directives: map[string][]string{
+				"default-src": {"'self'"},
+			},
+			expected: "default-src 'self'; " +
+				"script-src 'self' 'nonce-n0nce' 'wasm-unsafe-eval' 'sha384-h4sh'; " +
+				"style-src 'self' 'nonce-n0nce' 'sha384-st7le'",
+		},
+		{
+			scenario: "style sources allowing unsafe inline are not completed",
+			directives: map[string][]string{
+				"script-src": {"'self'"},
+				"style-src":  {"'self'", "'unsafe-inline'"},
+			},
+			expected: "script-src 'self' 'nonce-n0nce' 'wasm-unsafe-eval' 'sha384-h4sh'; " +
+				"style-src 'self' 'unsafe-inline'",
+		},
+		{
+			scenario: "default sources allowing unsafe inline are not completed for styles",
+			directives: map[string][]string{
+				"default-src": {"'self'", "'unsafe-inline'"},
+			},
+			expected: "default-src 'self' 'unsafe-inline'; " +
+				"script-src 'self' 'unsafe-inline' 'nonce-n0nce' 'wasm-unsafe-eval' 'sha384-h4sh'",
+		},
+		{
+			scenario: "unrestricted sources are not completed",
+			directives: map[string][]string{
+				"frame-ancestors": {"'none'"},
+			},
+			expected: "frame-ancestors 'none'",
+		},
+		{
+			scenario: "existing sources are not duplicated",
+			directives: map[string][]string{
+				"Script-Src": {"'self'", "'wasm-unsafe-eval'"},
+			},
+			expected: "script-src 'self' 'wasm-unsafe-eval' 'nonce-n0nce' 'sha384-h4sh'",
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			p := ContentSecurityPolicy{Directives: u.directives}
+			require.True(t, p.enabled())
+			require.Equal(t, u.expected, p.header("n0nce", []string{"sha384-h4sh"}, []string{"sha384-st7le"}))
+		})
+	}
+}
+
+func TestContentSecurityPolicyHeaderName(t *testing.T) {
+	require.Equal(t, "Content-Security-Policy", ContentSecurityPolicy{}.headerName())
+	require.Equal(t, "Content-Security-Policy-Report-Only", ContentSecurityPolicy{ReportOnly: true}.headerName())
+}
+
+func TestNewCSPNonce(t *testing.T) {
+	nonce := newCSPNonce()
+	require.Len(t, nonce, 24)
+	require.NotEqual(t, nonce, newCSPNonce())
+}
+
+func TestContentSecurityPolicyStyleNonce(t *testing.T) {
+	require.True(t, ContentSecurityPolicy{Directives: map[string][]string{
+		"default-src": {"'self'"},
+	}}.styleNonce())
+	require.False(t, ContentSecurityPolicy{Directives: map[string][]string{
+		"default-src": {"'self'", "'unsafe-inline'"},
+	}}.styleNonce())
+	require.False(t, ContentSecurityPolicy{Directives: map[string][]string{
+		"Style-Src": {"'unsafe-inline'"},
+	}}.styleNonce())
+	require.True(t, ContentSecurityPolicy{Directives: map[string][]string{
+		"default-src": {"'unsafe-inline'"},
+		"style-src":   {"'self'"},
+	}}.styleNonce())
+}
+
+func TestInlineHashes(t *testing.T) {
+	hashes := inlineHashes(`<meta name="x">`+
+		`<script>console.log("hello")</script>`+
+		`<SCRIPT async src="/analytics.js"></SCRIPT>`+
+		`<script type="module">console.log("bye")</script>`, "script")
+	require.Equal(t, []string{
+		cspHash([]byte(`console.log("hello")`)),
+		cspHash([]byte(`console.log("bye")`)),
+	}, hashes)
+
+	require.Empty(t, inlineHashes(`<link rel="stylesheet" href="/x.css">`, "script"))
+
+	hashes = inlineHashes(`<script>console.log("hello")</script>`+
+		`<style>body { margin: 0; }</style>`, "style")
+	require.Equal(t, []string{cspHash([]byte(`body { margin: 0; }`))}, hashes)
+}
+
+func TestSetNonce(t *testing.T) {
+	newDocument := func() UI {
+		return Html().privateBody(
+			Head().Body(
+				Meta().Charset("UTF-8"),
+				Link().Rel("stylesheet").Href("/app.css"),
+				Script().Src("/app.js"),
+				Style().Text("body { margin: 0; }"),
+			),
+		)
+	}
+
+	t.Run("scripts and styles", func(t *testing.T) {
+		document := newDocument()
+		setNonce(document, "n0nce", true)
+
+		head := document.(HTML).body()[0].(HTML)
+		require.Empty(t, head.body()[0].(HTML).attrs()["nonce"])
+		require.Equal(t, "n0nce", head.body()[1].(HTML).attrs()["nonce"])
+		require.Equal(t, "n0nce", head.body()[2].(HTML).attrs()["nonce"])
+		require.Equal(t, "n0nce", head.body()[3].(HTML).attrs()["nonce"])
+	})
+
+	t.Run("scripts only", func(t *testing.T) {
+		document := newDocument()
+		setNonce(document, "n0nce", false)
+
+		head := document.(HTML).body()[0].(HTML)
+		require.Empty(t, head.body()[0].(HTML).attrs()["nonce"])
+		require.Empty(t, head.body()[1].(HTML).attrs()["nonce"])
+		require.Equal(t, "n0nce", head.body()[2].(HTML).attrs()["nonce"])
+		require.Empty(t, head.body()[3].(HTML).attrs()["nonce"])
+	})
+}
@@ -4,8 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -24,7 +28,17 @@ type engineX struct {
 	internalURLs   []string
 	resolveURL     func(string) string
 	originPage     *requestPage
+	request        *http.Request
 	lastVisitedURL *url.URL
+	routeParams    map[string]string
+	navigations    int
+	redirects      int
+	loading        bool
+	layouts        []mountedLayout
+	hydrate        bool
+	streaming      bool
+	scriptNonce    string
+	pendingAsyncs  map[Composer]int
 
 	nodes   nodeManager
 	updates updateManager
@@ -34,9 +48,11 @@ type engineX struct {
 	defers     chan func()
 	goroutines sync.WaitGroup
 
-	asynchronousActionHandlers map[string]ActionHandler
-	actions                    actionManager
-	states                     stateManager
+	asynchronousActionHandlers  map[string]ActionHandler
+	asynchronousActionPolicies  map[string]ActionPolicy
+	asynchronousRequestHandlers map[string]RequestHandler
+	actions                     actionManager
+	states                      stateManager
 }
 
 func newEngine(ctx context.Context, routes *router, resolveURL func(string) string, originPage *requestPage, actionHandlers map[string]ActionHandler) *engineX {
@@ -56,17 +72,25 @@ func newEngine(ctx context.Context, routes *router, resolveURL func(string) stri
 	originPage.resolveURL = resolveURL
 
 	engine := &engineX{
-		ctx:                        ctx,
-		routes:                     routes,
-		resolveURL:                 resolveURL,
-		originPage:                 originPage,
-		localStorage:               localStorage,
-		lastVisitedURL:             &url.URL{},
-		sessionStorage:             sessionStorage,
-		nodes:                      nodeManager{},
-		dispatches:                 make(chan func(), 4096),
-		defers:                     make(chan func(), 4096),
-		asynchronousActionHandlers: actionHandlers,
+		ctx:                         ctx,
+		routes:                      routes,
+		resolveURL:                  resolveURL,
+		originPage:                  originPage,
+		localStorage:                localStorage,
+		lastVisitedURL:              &url.URL{},
+		sessionStorage:              sessionStorage,
+		nodes:                       nodeManager{},
+		pendingAsyncs:               make(map[Composer]int),
+		dispatches:                  make(chan func(), 4096),
+		defers:                      make(chan func(), 4096),
+		asynchronousActionHandlers:  actionHandlers,
+		asynchronousActionPolicies:  actionPolicies,
+		asynchronousRequestHandlers: requestHandlers,
+		actions: actionManager{
+			middlewares: actionMiddlewares,
+			broadcasts:  broadcastedActions,
+		},
+		states: stateManager{timedExpirations: IsClient},
 	}
 
 	engine.initBrowser()
@@ -79,20 +103,35 @@ func (e *engineX) baseContext() Context {
 		resolveURL:            e.resolveURL,
 		appUpdatable:          e.browser.AppUpdatable,
 		page:                  e.page,
+		routeParam:            e.routeParam,
+		requestHeader:         e.requestHeader,
+		requestCookie:         e.requestCookie,
 		navigate:              e.Navigate,
 		localStorage:          e.localStorage,
 		sessionStorage:        e.sessionStorage,
 		dispatch:              e.dispatch,
 		defere:                e.defere,
-		async:                 e.async,
+		async:                 e.asyncFrom,
 		addComponentUpdate:    e.updates.Add,
 		removeComponentUpdate: e.updates.Done,
 		handleAction:          e.actions.Handle,
+		handleActionPolicy:    e.actions.HandleWithPolicy,
 		postAction:            e.actions.Post,
+		useActionMiddleware:   e.actions.Use,
+		handleRequest:         e.actions.HandleRequest,
+		request:               e.actions.Request,
 		observeState:          e.states.Observe,
 		getState:              e.states.Get,
 		setState:              e.states.Set,
 		delState:              e.states.Delete,
+		computeState:          e.states.Compute,
+		undoState:             e.states.Undo,
+		redoState:             e.states.Redo,
+		canUndoState:          e.states.CanUndo,
+		canRedoState:          e.states.CanRedo,
+		groupStateHistory:     e.states.GroupHistory,
+		updateStates:          e.states.Transaction,
+		broadcastAction:       e.states.BroadcastAction,
 
 		notifyComponentEvent: e.nodes.NotifyComponentEvent,
 	}
@@ -121,23 +160,9 @@ func (e *engineX) Navigate(destination *url.URL, updateHistory bool) {
 		return
 	}
 
-	defer func() {
-		if updateHistory {
-			Window().addHistory(destination)
-		}
-		e.lastVisitedURL = destination
-
-		e.nodes.NotifyComponentEvent(e.baseContext(), e.body, nav{})
-
-		if destination.Fragment != "" {
-			e.defere(func() {
-				Window().ScrollToID(destination.Fragment)
-			})
-		}
-	}()
-
 	if destination.Path == e.lastVisitedURL.Path &&
 		destination.Fragment != e.lastVisitedURL.Fragment {
+		e.completeNavigation(destination, updateHistory)
 		return
 	}
 
@@ -145,16 +170,188 @@ func (e *engineX) Navigate(destination *url.URL, updateHistory bool) {
 	if !strings.HasPrefix(path, "/") {
 		path = "/" + path
 	}
-	root, ok := e.routes.createComponent(path)
+	root, params, ok := e.routes.createComponent(path)
 	if !ok {
 		root = &notFound{}
 	}
 
-	if err := e.Load(root); err != nil {
-		panic(errors.New("loading component failed").
-			WithTag("component-type", reflect.TypeOf(root)).
-			Wrap(err))
+	navigation := &Navigation{To: destination}
+	if e.lastVisitedURL.String() != "" {
+		navigation.From = e.lastVisitedURL
+	}
+
+	if e.body != nil {
+		e.nodes.NotifyBeforeLeave(e.baseContext(), e.body, navigation)
+		if e.interruptNavigation(navigation, updateHistory) {
+			return
+		}
+	}
+
+	e.navigations++
+	navigationID := e.navigations
+	e.loading = false
+
+	ctx := e.baseContext()
+	ctx.sourceElement = root
+	ctx.routeParam = func(name string) string {
+		return params[name]
+	}
+	if enterer, ok := root.(BeforeEnterer); ok {
+		enterer.OnBeforeEnter(ctx, navigation)
+		if e.interruptNavigation(navigation, updateHistory) {
+			return
+		}
+	}
+
+	commit := func() {
+		e.routeParams = params
+		if err := e.loadWithLayouts(path, root); err != nil {
+			panic(errors.New("loading component failed").
+				WithTag("component-type", reflect.TypeOf(root)).
+				Wrap(err))
+		}
+		e.completeNavigation(destination, updateHistory)
+	}
+
+	loader, ok := root.(Loader)
+	if !ok {
+		commit()
+		return
+	}
+
+	e.loading = true
+	ctx.Async(func() {
+		err := loader.OnLoad(ctx, navigation)
+
+		e.dispatch(func() {
+			if navigationID != e.navigations {
+				return
+			}
+			e.loading = false
+
+			if err != nil {
+				Log(errors.New("loading navigation data failed").
+					WithTag("url", destination).
+					WithTag("component-type", reflect.TypeOf(root)).
+					Wrap(err))
+				navigation.Cancel()
+			}
+			if e.interruptNavigation(navigation, updateHistory) {
+				return
+			}
+			commit()
+		})
+	})
+}
+
+// completeNavigation records the given destination as the visited URL and
+// notifies the mounted components about the navigation.
+func (e *engineX) completeNavigation(destination *url.URL, updateHistory bool) {
+	if updateHistory {
+		Window().addHistory(destination)
+	}
+	e.lastVisitedURL = destination
+	e.redirects = 0
+
+	e.nodes.NotifyComponentEvent(e.baseContext(), e.body, nav{})
+
+	if destination.Fragment != "" {
+		e.defere(func() {
+			Window().ScrollToID(destination.Fragment)
+		})
+	}
+}
+
+// interruptNavigation reports whether the given navigation was cancelled or
+// redirected. When the navigation was initiated by the browser history, the
+// history is restored to the last visited URL. When pre-rendering, a redirect
+// is answered with an HTTP redirect. In the web browser, the history entry of
+// a navigation initiated by the browser is replaced by the redirect URL, and
+// a navigation redirected more than maxNavigationRedirects times in a row is
+// cancelled.
+func (e *engineX) interruptNavigation(n *Navigation, updateHistory bool) bool {
+	switch {
+	case n.redirect != nil && IsServer:
+		e.originPage.Redirect(n.redirect.String(), http.StatusFound)
+		return true
+
+	case n.redirect != nil && e.redirects >= maxNavigationRedirects:
+		Log(errors.New("redirecting navigation failed").
+			WithTag("reason", "too many redirects").
+			WithTag("url", n.redirect))
+		e.redirects = 0
+		e.restoreHistory(updateHistory)
+		return true
+
+	case n.redirect != nil:
+		e.redirects++
+		if !updateHistory {
+			Window().replaceHistory(n.redirect)
+		}
+		e.Navigate(n.redirect, updateHistory)
+		return true
+
+	case n.cancelled:
+		e.redirects = 0
+		e.restoreHistory(updateHistory)
+		return true
+
+	default:
+		return false
+	}
+}
+
+// restoreHistory restores the browser history to the last visited URL when
+// the interrupted navigation was initiated by the browser history.
+func (e *engineX) restoreHistory(updateHistory bool) {
+	if !updateHistory && e.lastVisitedURL.String() != "" {
+		Window().addHistory(e.lastVisitedURL)
+	}
+}
+
+// loadWithLayouts loads the given component wrapped into the layouts of the
+// given path. Layouts that were already mounted for the previous path are kept,
+// and only the innermost of them is updated with its new outlet.
+func (e *engineX) loadWithLayouts(path string, v Composer) error {
+	routes := e.routes.layoutsOf(path)
+	layouts := make([]mountedLayout, len(routes))
+	reused := -1
+	for i, r := range routes {
+		if i == reused+1 &&
+			i < len(e.layouts) &&
+			e.layouts[i].prefix == r.prefix &&
+			e.layouts[i].layout.Mounted() {
+			layouts[i] = e.layouts[i]
+			reused = i
+			continue
+		}
+
+		layouts[i] = mountedLayout{
+			prefix: r.prefix,
+			layout: Keyed(layoutKey{prefix: r.prefix}, r.newLayout()).(Layout),
+		}
+	}
+	e.layouts = layouts
+
+	var content Composer = v
+	for i := len(layouts) - 1; i > reused; i-- {
+		layouts[i].layout.SetOutlet(content)
+		content = layouts[i].layout
+	}
+
+	if reused < 0 {
+		return e.Load(content)
 	}
+
+	layout := layouts[reused].layout
+	layout.SetOutlet(content)
+	if _, err := e.nodes.UpdateComponentRoot(e.baseContext(), layout); err != nil {
+		return errors.New("updating layout failed").
+			WithTag("layout-type", reflect.TypeOf(layout)).
+			WithTag("prefix", layouts[reused].prefix).
+			Wrap(err)
+	}
+	return nil
 }
 
 func (e *engineX) initBrowser() {
@@ -197,7 +394,40 @@ func (e *engineX) page() Page {
 	return e.originPage
 }
 
+func (e *engineX) routeParam(name string) string {
+	return e.routeParams[name]
+}
+
+func (e *engineX) requestHeader(name string) string {
+	if e.request == nil {
+		return ""
+	}
+	return e.request.Header.Get(name)
+}
+
+func (e *engineX) requestCookie(name string) string {
+	r := e.request
+	if r == nil && IsClient {
+		r = &http.Request{Header: http.Header{
+			"Cookie": {Window().Get("document").Get("cookie").String()},
+		}}
+	}
+	if r == nil {
+		return ""
+	}
+
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
 func (e *engineX) Load(v Composer) error {
+	if e.body == nil && e.hydrate {
+		return e.hydrateBody(v)
+	}
+
 	if e.body == nil {
 		body := Body()
 		body = body.setJSElement(Window().Get("document").Get("body")).(HTMLBody)
@@ -208,10 +438,7 @@ func (e *engineX) Load(v Composer) error {
 
 		body = body.setBody([]UI{firstChild}).(HTMLBody)
 		e.body = body
-
-		for action, handler := range e.asynchronousActionHandlers {
-			e.actions.Handle(action, body, true, handler)
-		}
+		e.handleAsynchronousActions()
 	}
 
 	body, err := e.nodes.Update(e.baseContext(), e.body, Body().privateBody(v))
@@ -222,6 +449,32 @@ func (e *engineX) Load(v Composer) error {
 	return nil
 }
 
+// hydrateBody loads the given component by adopting the root element that was
+// pre-rendered by the server as the first child of the document body.
+func (e *engineX) hydrateBody(v Composer) error {
+	body := Body()
+	body = body.setJSElement(Window().Get("document").Get("body")).(HTMLBody)
+
+	root, err := e.nodes.Hydrate(e.baseContext(), 1, v, body.JSValue(), body.JSValue().firstElementChild())
+	if err != nil {
+		return errors.New("hydrating root failed").Wrap(err)
+	}
+	root = root.setParent(body)
+
+	e.body = body.setBody([]UI{root}).(HTMLBody)
+	e.handleAsynchronousActions()
+	return nil
+}
+
+func (e *engineX) handleAsynchronousActions() {
+	for action, handler := range e.asynchronousActionHandlers {
+		e.actions.HandleWithPolicy(action, e.body, true, handler, e.asynchronousActionPolicies[action])
+	}
+	for action, handler := range e.asynchronousRequestHandlers {
+		e.actions.HandleRequest(action, e.body, true, handler)
+	}
+}
+
 // Start initiates the main event loop of the engine at the specified framerate.
 // The loop efficiently manages dispatches, component updates, and deferred
 // actions.
@@ -237,6 +490,9 @@ func (e *engineX) Start(framerate int) {
 	defer frames.Stop()
 
 	e.states.CleanupExpiredPersistedStates(e.baseContext())
+	if len(broadcastedActions) != 0 {
+		e.states.initBroadcast(e.baseContext())
+	}
 
 	for {
 		select {
@@ -264,7 +520,8 @@ func (e *engineX) processFrame() {
 			return
 		}
 
-		if _, err := e.nodes.UpdateComponentRoot(e.baseContext(), c); err != nil {
+		ctx := e.baseContext()
+		if _, err := e.nodes.UpdateComponentRoot(ctx, c); err != nil && !e.nodes.CatchError(ctx, c, err) {
 			panic(errors.New("updating component failed").Wrap(err))
 		}
 	})
@@ -314,6 +571,41 @@ func (e *engineX) ConsumeAll() {
 	}
 }
 
+// consumeNavigation executes the dispatches in the queue until the ongoing
+// navigation is no longer waiting for its loader, then consumes the remaining
+// dispatches without waiting for other goroutines to finish. It returns an
+// error when the engine context is done before the navigation is loaded.
+func (e *engineX) consumeNavigation() error {
+	for e.loading {
+		select {
+		case dispatch := <-e.dispatches:
+			dispatch()
+
+		case <-e.ctx.Done():
+			return e.ctx.Err()
+		}
+	}
+	e.consumeDispatches()
+	return nil
+}
+
+// consumeDispatches executes all the dispatches in the queue and processes a
+// frame, without waiting for ongoing goroutines to finish.
+func (e *engineX) consumeDispatches() {
+	for {
+		select {
+		case dispatch := <-e.dispatches:
+			dispatch()
+
+		default:
+			e.processFrame()
+			if len(e.dispatches) == 0 {
+				return
+			}
+		}
+	}
+}
+
 // Encode serializes the given HTML element, integrating the engine's root
 // component as the initial child within the document's body. The final HTML
 // content, including the standard DOCTYPE declaration, is written  to the
@@ -322,29 +614,199 @@ func (e *engineX) Encode(w *bytes.Buffer, document HTMLHtml) error {
 	if e.body == nil {
 		return errors.New("no component loaded")
 	}
-	root := e.body.body()[0]
 
-	var body HTML
-	for _, child := range document.(HTML).body() {
-		if child, isBody := child.(HTMLBody); isBody {
-			body = child
-			break
-		}
+	body, err := documentBody(document)
+	if err != nil {
+		return err
 	}
-	if body == nil {
-		return errors.New("document does not have a body")
+	prependBody(body, e.body.body()[0])
+	if states := e.transferredStatesScript(); states != nil {
+		body.setBody(append(body.body(), states))
 	}
 
-	children := make([]UI, 0, len(body.body())+1)
-	children = append(children, root)
-	children = append(children, body.body()...)
-	body.setBody(children)
-
 	w.WriteString("<!doctype html>\n")
 	e.nodes.Encode(e.baseContext(), w, document)
 	return nil
 }
 
+// EncodeStream serializes the given HTML element like Encode, without waiting
+// for asynchronous work to finish. The document is written and flushed
+// immediately, with placeholders in place of the components that have ongoing
+// goroutines started with Context.Async. The content of those components is
+// then written as their goroutines finish, followed by an inline script that
+// swaps it into the matching placeholder. The engine must be in streaming mode
+// before navigating. Streaming stops with an error when the engine context is
+// done, such as when the client disconnects.
+func (e *engineX) EncodeStream(w http.ResponseWriter, status int, document HTMLHtml) error {
+	if e.body == nil && len(e.pendingAsyncs) == 0 {
+		return errors.New("no component loaded")
+	}
+
+	body, err := documentBody(document)
+	if err != nil {
+		return err
+	}
+
+	var boundaries []streamBoundary
+	var boundaryCount int
+	var encoding UI
+	addBoundary := func(c Composer) UI {
+		boundaryCount++
+		boundaries = append(boundaries, streamBoundary{
+			id:        boundaryCount,
+			component: c,
+		})
+		return Template().ID(streamPlaceholderID(boundaryCount))
+	}
+
+	ctx := e.baseContext()
+	ctx.encodePlaceholder = func(c Composer) (UI, bool) {
+		if c == encoding || e.pendingAsyncs[c] == 0 {
+			return nil, false
+		}
+		return addBoundary(c), true
+	}
+
+	if e.body != nil {
+		prependBody(body, e.body.body()[0])
+	} else {
+		prependBody(body, addBoundary(nil))
+	}
+
+	var b bytes.Buffer
+	b.WriteString("<!doctype html>\n")
+	e.nodes.Encode(ctx, &b, document)
+	shell := b.String()
+	bodyEnd := strings.LastIndex(shell, "</body>")
+
+	flush := func() {
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+
+	w.WriteHeader(status)
+	io.WriteString(w, shell[:bodyEnd])
+	if len(boundaries) != 0 {
+		io.WriteString(w, "\n"+e.scriptTag()+streamSwapScript+"</script>\n")
+	}
+	flush()
+
+	for len(boundaries) != 0 {
+		e.consumeDispatches()
+
+		pending := boundaries
+		boundaries = nil
+		streamed := false
+
+		for _, boundary := range pending {
+			var content UI
+			switch {
+			case boundary.component == nil:
+				if e.body != nil {
+					content = e.body.body()[0]
+				}
+
+			case !boundary.component.Mounted():
+				continue
+
+			case e.pendingAsyncs[boundary.component] == 0:
+				content = boundary.component
+				encoding = content
+			}
+			if content == nil {
+				boundaries = append(boundaries, boundary)
+				continue
+			}
+
+			b.Reset()
+			e.nodes.Encode(ctx, &b, content)
+			encoding = nil
+
+			fmt.Fprintf(w, "<template id=%q>\n%s\n</template>\n%sgoappStream(%d)</script>\n",
+				streamChunkID(boundary.id),
+				b.String(),
+				e.scriptTag(),
+				boundary.id,
+			)
+			streamed = true
+		}
+		if streamed {
+			flush()
+		}
+
+		if len(boundaries) == 0 {
+			break
+		}
+		if len(e.pendingAsyncs) == 0 && len(e.dispatches) == 0 {
+			Log(errors.New("streaming page content failed").
+				WithTag("reason", "content is no longer loading").
+				WithTag("unresolved-placeholders", len(boundaries)))
+			break
+		}
+
+		select {
+		case dispatch := <-e.dispatches:
+			dispatch()
+
+		case <-e.ctx.Done():
+			return errors.New("streaming page content interrupted").Wrap(e.ctx.Err())
+		}
+	}
+
+	if states := e.transferredStatesScript(); states != nil {
+		b.Reset()
+		e.nodes.Encode(ctx, &b, states)
+		b.WriteByte('\n')
+		w.Write(b.Bytes())
+	}
+
+	io.WriteString(w, shell[bodyEnd:])
+	flush()
+	return nil
+}
+
+// transferredStatesScript returns a script that contains the states to
+// transfer to the browser. It returns nil when there are no such states.
+func (e *engineX) transferredStatesScript() UI {
+	states, err := e.states.TransferredStates()
+	if err != nil {
+		Log(errors.New("transferring states failed").Wrap(err))
+		return nil
+	}
+	if states == nil {
+		return nil
+	}
+	return Raw(`<script id="` + transferredStatesID + `" type="application/json">` +
+		string(states) +
+		`</script>`)
+}
+
+// restoreTransferredStates restores the states transferred by the server with
+// the pre-rendered page.
+func (e *engineX) restoreTransferredStates() {
+	script := Window().
+		Get("document").
+		Call("getElementById", transferredStatesID)
+	if !script.Truthy() {
+		return
+	}
+
+	if err := e.states.RestoreTransferredStates([]byte(script.Get("textContent").String())); err != nil {
+		Log(errors.New("restoring transferred states failed").Wrap(err))
+	}
+	script.Call("remove")
+}
+
+// scriptTag returns the opening tag of the inline scripts written by the
+// engine, which carries the script nonce when there is one.
+func (e *engineX) scriptTag() string {
+	if e.scriptNonce == "" {
+		return "<script>"
+	}
+	return "<script nonce=" + strconv.Quote(e.scriptNonce) + ">"
+}
+
 func (e *engineX) dispatch(v func()) {
 	e.dispatches <- v
 }
@@ -353,6 +815,29 @@ func (e *engineX) defere(v func()) {
 	e.defers <- v
 }
 
+// asyncFrom launches the given function in a goroutine on behalf of the given
+// UI element. In streaming mode, the component that owns the element is
+// considered as loading until the function returns.
+func (e *engineX) asyncFrom(src UI, v func()) {
+	c, ok := component(src)
+	if !e.streaming || !ok {
+		e.async(v)
+		return
+	}
+
+	e.dispatch(func() {
+		e.pendingAsyncs[c]++
+	})
+	e.async(func() {
+		v()
+		e.dispatch(func() {
+			if e.pendingAsyncs[c]--; e.pendingAsyncs[c] <= 0 {
+				delete(e.pendingAsyncs, c)
+			}
+		})
+	})
+}
+
 func (e *engineX) async(v func()) {
 	e.goroutines.Add(1)
 	go func() {
@@ -360,3 +845,59 @@ func (e *engineX) async(v func()) {
 		e.goroutines.Done()
 	}()
 }
+
+type mountedLayout struct {
+	prefix string
+	layout Layout
+}
+
+type layoutKey struct {
+	prefix string
+}
+
+const transferredStatesID = "goapp-states"
+
+// maxNavigationRedirects is the maximum number of consecutive redirects a
+// navigation can go through in the web browser.
+const maxNavigationRedirects = 10
+
+type streamBoundary struct {
+	id        int
+	component Composer
+}
+
+func streamPlaceholderID(id int) string {
+	return "goapp-stream-" + strconv.Itoa(id)
+}
+
+func streamChunkID(id int) string {
+	return "goapp-stream-chunk-" + strconv.Itoa(id)
+}
+
+const streamSwapScript = `function goappStream(id) {
+  var placeholder = document.getElementById("goapp-stream-" + id);
+  var chunk = document.getElementById("goapp-stream-chunk-" + id);
+  if (placeholder && chunk) {
+    placeholder.replaceWith(chunk.content);
+  }
+  if (chunk) {
+    chunk.remove();
+  }
+  document.currentScript.remove();
+}`
+
+func documentBody(document HTMLHtml) (HTML, error) {
+	for _, child := range document.(HTML).body() {
+		if child, isBody := child.(HTMLBody); isBody {
+			return child, nil
+		}
+	}
+	return nil, errors.New("document does not have a body")
+}
+
+func prependBody(body HTML, v UI) {
+	children := make([]UI, 0, len(body.body())+1)
+	children = append(children, v)
+	children = append(children, body.body()...)
+	body.setBody(children)
+}
@@ -3,10 +3,15 @@ package app
 import (
 	"bytes"
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/maxence-charriere/go-app/v10/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,6 +20,21 @@ func TestEngineBaseContext(t *testing.T) {
 	ctx := e.baseContext()
 	require.NotNil(t, ctx.Context)
 	require.NotNil(t, ctx.page)
+	require.NotNil(t, ctx.routeParam)
+	require.NotNil(t, ctx.requestHeader)
+	require.NotNil(t, ctx.requestCookie)
+	require.NotNil(t, ctx.computeState)
+	require.NotNil(t, ctx.undoState)
+	require.NotNil(t, ctx.redoState)
+	require.NotNil(t, ctx.canUndoState)
+	require.NotNil(t, ctx.canRedoState)
+	require.NotNil(t, ctx.groupStateHistory)
+	require.NotNil(t, ctx.updateStates)
+	require.NotNil(t, ctx.broadcastAction)
+	require.NotNil(t, ctx.useActionMiddleware)
+	require.NotNil(t, ctx.handleRequest)
+	require.NotNil(t, ctx.handleActionPolicy)
+	require.NotNil(t, ctx.request)
 	require.NotNil(t, ctx.resolveURL)
 	require.NotNil(t, ctx.navigate)
 	require.NotNil(t, ctx.localStorage)
@@ -55,6 +75,27 @@ func TestEngineLoad(t *testing.T) {
 		require.IsType(t, &bar{}, e.body.body()[0])
 	})
 
+	t.Run("load hydrates a new body", func(t *testing.T) {
+		e := newTestEngine()
+		e.hydrate = true
+		err := e.Load(&hello{})
+		require.NoError(t, err)
+		require.IsType(t, &hello{}, e.body.body()[0])
+		require.True(t, e.body.body()[0].Mounted())
+
+		err = e.Load(&bar{})
+		require.NoError(t, err)
+		require.IsType(t, &bar{}, e.body.body()[0])
+	})
+
+	t.Run("hydrating a non mountable component returns an error", func(t *testing.T) {
+		e := newTestEngine()
+		e.hydrate = true
+		err := e.Load(&compoWithNilRendering{})
+		require.Error(t, err)
+		t.Log(err)
+	})
+
 	t.Run("load body update with a non mountable component panics", func(t *testing.T) {
 		e := newTestEngine()
 		e.Load(&hello{})
@@ -83,6 +124,178 @@ func TestEngineNavigate(t *testing.T) {
 		require.Equal(t, "/hello", e.lastVisitedURL.Path)
 	})
 
+	t.Run("url with parameters is loaded", func(t *testing.T) {
+		e := newTestEngine()
+		e.routes.route("/users/{id}", NewZeroComponentFactory(&hello{}))
+
+		destination, _ := url.Parse("/users/42")
+		e.Navigate(destination, false)
+		require.IsType(t, &hello{}, e.body.body()[0])
+		require.Equal(t, "42", e.baseContext().Param("id"))
+		require.Empty(t, e.baseContext().Param("name"))
+	})
+
+	t.Run("url with layout is loaded", func(t *testing.T) {
+		e := newTestEngine()
+		e.routes.routeLayout("/docs", func() Layout { return &layoutCompo{} })
+		e.routes.route("/docs/a", NewZeroComponentFactory(&hello{}))
+		e.routes.route("/docs/b", NewZeroComponentFactory(&bar{}))
+
+		destination, _ := url.Parse("/docs/a")
+		e.Navigate(destination, false)
+		layout := e.body.body()[0]
+		require.IsType(t, &layoutCompo{}, layout)
+		require.IsType(t, &hello{}, layout.(*layoutCompo).root().(HTML).body()[0])
+
+		destination, _ = url.Parse("/docs/b")
+		e.Navigate(destination, false)
+		require.Same(t, layout, e.body.body()[0])
+		require.True(t, layout.Mounted())
+		require.IsType(t, &bar{}, layout.(*layoutCompo).root().(HTML).body()[0])
+	})
+
+	t.Run("url outside of layout is loaded", func(t *testing.T) {
+		e := newTestEngine()
+		e.routes.routeLayout("/docs", func() Layout { return &layoutCompo{} })
+		e.routes.route("/docs", NewZeroComponentFactory(&hello{}))
+		e.routes.route("/about", NewZeroComponentFactory(&bar{}))
+
+		destination, _ := url.Parse("/docs")
+		e.Navigate(destination, false)
+		layout := e.body.body()[0]
+		require.IsType(t, &layoutCompo{}, layout)
+
+		destination, _ = url.Parse("/about")
+		e.Navigate(destination, false)
+		require.IsType(t, &bar{}, e.body.body()[0])
+		require.False(t, layout.Mounted())
+		require.Empty(t, e.layouts)
+	})
+
+	t.Run("url with nested layouts is loaded", func(t *testing.T) {
+		e := newTestEngine()
+		e.routes.routeLayout("/", func() Layout { return &layoutCompo{} })
+		e.routes.routeLayout("/docs", func() Layout { return &layoutCompo{} })
+		e.routes.route("/", NewZeroComponentFactory(&bar{}))
+		e.routes.route("/docs/a", NewZeroComponentFactory(&hello{}))
+
+		destination, _ := url.Parse("/")
+		e.Navigate(destination, false)
+		root := e.body.body()[0]
+		require.IsType(t, &layoutCompo{}, root)
+		require.IsType(t, &bar{}, root.(*layoutCompo).root().(HTML).body()[0])
+
+		destination, _ = url.Parse("/docs/a")
+		e.Navigate(destination, false)
+		require.Same(t, root, e.body.body()[0])
+		docsLayout := root.(*layoutCompo).root().(HTML).body()[0]
+		require.IsType(t, &layoutCompo{}, docsLayout)
+		require.NotSame(t, root, docsLayout)
+		require.IsType(t, &hello{}, docsLayout.(*layoutCompo).root().(HTML).body()[0])
+		require.Len(t, e.layouts, 2)
+	})
+
+	t.Run("navigation cancelled by the current page is skipped", func(t *testing.T) {
+		e := newTestEngine()
+		e.routes.route("/guard", func() Composer { return &guardCompo{cancelLeave: true} })
+		e.routes.route("/hello", NewZeroComponentFactory(&hello{}))
+
+		destination, _ := url.Parse("/guard")
+		e.Navigate(destination, false)
+		require.IsType(t, &guardCompo{}, e.body.body()[0])
+
+		destination, _ = url.Parse("/hello")
+		e.Navigate(destination, false)
+		require.IsType(t, &guardCompo{}, e.body.body()[0])
+		require.Equal(t, "/guard", e.lastVisitedURL.Path)
+		require.Equal(t, "/hello", e.body.body()[0].(*guardCompo).leaving.To.Path)
+	})
+
+	t.Run("navigation cancelled by the destination is skipped", func(t *testing.T) {
+		e := newTestEngine()
+		e.routes.route("/hello", NewZeroComponentFactory(&hello{}))
+		e.routes.route("/guard", func() Composer { return &guardCompo{cancelEnter: true} })
+
+		destination, _ := url.Parse("/hello")
+		e.Navigate(destination, false)
+
+		destination, _ = url.Parse("/guard")
+		e.Navigate(destination, false)
+		require.IsType(t, &hello{}, e.body.body()[0])
+		require.Equal(t, "/hello", e.lastVisitedURL.Path)
+	})
+
+	t.Run("navigation redirected in a loop is cancelled", func(t *testing.T) {
+		testSkipNonWasm(t)
+
+		e := newTestEngine()
+		e.routes.route("/ping", func() Composer { return &guardCompo{redirectEnter: "/pong"} })
+		e.routes.route("/pong", func() Composer { return &guardCompo{redirectEnter: "/ping"} })
+
+		destination, _ := url.Parse("/ping")
+		e.Navigate(destination, true)
+		require.Nil(t, e.body)
+		require.Zero(t, e.redirects)
+	})
+
+	t.Run("navigation redirected by the destination is answered with a redirect", func(t *testing.T) {
+		e := newTestEngine()
+		e.routes.route("/hello", NewZeroComponentFactory(&hello{}))
+		e.routes.route("/guard", func() Composer { return &guardCompo{redirectEnter: "/hello"} })
+
+		destination, _ := url.Parse("/guard")
+		e.Navigate(destination, false)
+		require.Nil(t, e.body)
+		require.Equal(t, "/hello", e.originPage.redirectURL)
+		require.Equal(t, http.StatusFound, e.originPage.Status())
+	})
+
+	t.Run("navigation is delayed by the destination loader", func(t *testing.T) {
+		e := newTestEngine()
+		e.routes.route("/users/{id}", func() Composer { return &loaderCompo{} })
+
+		destination, _ := url.Parse("/users/42")
+		e.Navigate(destination, false)
+		require.Nil(t, e.body)
+		require.Empty(t, e.routeParam("id"))
+
+		e.ConsumeAll()
+		require.Equal(t, "42", e.routeParam("id"))
+		require.IsType(t, &loaderCompo{}, e.body.body()[0])
+		require.Equal(t, "user 42", e.body.body()[0].(*loaderCompo).User)
+		require.Equal(t, "/users/42", e.lastVisitedURL.Path)
+	})
+
+	t.Run("navigation is cancelled by the destination loader error", func(t *testing.T) {
+		e := newTestEngine()
+		e.routes.route("/hello", NewZeroComponentFactory(&hello{}))
+		e.routes.route("/users/{id}", func() Composer { return &loaderCompo{} })
+
+		destination, _ := url.Parse("/hello")
+		e.Navigate(destination, false)
+
+		destination, _ = url.Parse("/users/error")
+		e.Navigate(destination, false)
+		e.ConsumeAll()
+		require.IsType(t, &hello{}, e.body.body()[0])
+		require.Equal(t, "/hello", e.lastVisitedURL.Path)
+	})
+
+	t.Run("navigation superseded while loading is skipped", func(t *testing.T) {
+		e := newTestEngine()
+		e.routes.route("/hello", NewZeroComponentFactory(&hello{}))
+		e.routes.route("/users/{id}", func() Composer { return &loaderCompo{} })
+
+		destination, _ := url.Parse("/users/42")
+		e.Navigate(destination, false)
+
+		destination, _ = url.Parse("/hello")
+		e.Navigate(destination, false)
+		e.ConsumeAll()
+		require.IsType(t, &hello{}, e.body.body()[0])
+		require.Equal(t, "/hello", e.lastVisitedURL.Path)
+	})
+
 	t.Run("mailto is loaded", func(t *testing.T) {
 		e := newTestEngine()
 		destination, _ := url.Parse("mailto:contact@murlok.io")
@@ -195,6 +408,119 @@ func TestEngineExternalNavigation(t *testing.T) {
 	})
 }
 
+func TestEngineEncodeTransferredStates(t *testing.T) {
+	e := newTestEngine()
+	err := e.Load(&hello{})
+	require.NoError(t, err)
+	e.states.Set(e.baseContext(), "/greeting", "hello <b>world</b>").Transfer()
+
+	var b bytes.Buffer
+	err = e.Encode(&b, Html().privateBody(Body()))
+	require.NoError(t, err)
+	require.Contains(t, b.String(), `<script id="goapp-states" type="application/json">{"/greeting":{"Value":"hello \u003cb\u003eworld\u003c/b\u003e"`)
+}
+
+func TestEngineEncodeStream(t *testing.T) {
+	t.Run("pending component is streamed", func(t *testing.T) {
+		e := newTestEngine()
+		e.streaming = true
+		e.routes.route("/stream", func() Composer {
+			return &streamCompo{}
+		})
+
+		destination, _ := url.Parse("/stream")
+		e.Navigate(destination, false)
+		e.consumeDispatches()
+
+		w := httptest.NewRecorder()
+		err := e.EncodeStream(w, http.StatusOK, Html().privateBody(Body()))
+		require.NoError(t, err)
+		require.True(t, w.Flushed)
+
+		page := w.Body.String()
+		placeholder := strings.Index(page, `<template id="goapp-stream-1"></template>`)
+		chunk := strings.Index(page, `<template id="goapp-stream-chunk-1">`)
+		require.NotEqual(t, -1, placeholder)
+		require.Greater(t, chunk, placeholder)
+		require.NotContains(t, page[:chunk], "loaded")
+		require.Contains(t, page[chunk:], "<div>loaded</div>")
+		require.Contains(t, page, "<script>goappStream(1)</script>")
+		require.True(t, strings.HasSuffix(page, "</body>\n</html>"))
+	})
+
+	t.Run("loading page is streamed", func(t *testing.T) {
+		e := newTestEngine()
+		e.streaming = true
+		e.routes.route("/users/{id}", func() Composer { return &loaderCompo{} })
+
+		destination, _ := url.Parse("/users/42")
+		e.Navigate(destination, false)
+		e.consumeDispatches()
+		require.Nil(t, e.body)
+
+		w := httptest.NewRecorder()
+		err := e.EncodeStream(w, http.StatusOK, Html().privateBody(Body()))
+		require.NoError(t, err)
+
+		page := w.Body.String()
+		require.Contains(t, page, `<template id="goapp-stream-1"></template>`)
+		require.Contains(t, page, "user 42")
+	})
+
+	t.Run("streaming stops when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		e := newTestEngine()
+		e.ctx = ctx
+		e.streaming = true
+
+		release := make(chan struct{})
+		defer close(release)
+		e.routes.route("/blocked", func() Composer {
+			return &blockedStreamCompo{release: release}
+		})
+
+		destination, _ := url.Parse("/blocked")
+		e.Navigate(destination, false)
+		e.consumeDispatches()
+
+		cancel()
+		w := httptest.NewRecorder()
+		err := e.EncodeStream(w, http.StatusOK, Html().privateBody(Body()))
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Contains(t, w.Body.String(), `<template id="goapp-stream-1"></template>`)
+		require.NotContains(t, w.Body.String(), "</html>")
+	})
+
+	t.Run("waiting for the navigation stops when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		e := newTestEngine()
+		e.ctx = ctx
+		e.streaming = true
+
+		release := make(chan struct{})
+		defer close(release)
+		e.routes.route("/blocked", func() Composer {
+			return &blockedStreamCompo{release: release, loader: true}
+		})
+
+		destination, _ := url.Parse("/blocked")
+		e.Navigate(destination, false)
+		cancel()
+		require.True(t, errors.Is(e.consumeNavigation(), context.Canceled))
+		require.Nil(t, e.body)
+	})
+
+	t.Run("encoding without loaded component returns an error", func(t *testing.T) {
+		e := newTestEngine()
+		e.streaming = true
+
+		w := httptest.NewRecorder()
+		err := e.EncodeStream(w, http.StatusOK, Html().privateBody(Body()))
+		require.Error(t, err)
+		require.Zero(t, w.Body.Len())
+	})
+}
+
 func TestEngineAsync(t *testing.T) {
 	e := newTestEngine()
 
@@ -273,3 +599,111 @@ func TestEngineEncode(t *testing.T) {
 func newTestEngine() *engineX {
 	return NewTestEngine().(*engineX)
 }
+
+type layoutCompo struct {
+	Compo
+
+	content UI
+}
+
+func (l *layoutCompo) SetOutlet(v UI) {
+	l.content = v
+}
+
+func (l *layoutCompo) Render() UI {
+	return Div().Body(l.content)
+}
+
+type guardCompo struct {
+	Compo
+
+	cancelLeave   bool
+	cancelEnter   bool
+	redirectEnter string
+	leaving       *Navigation
+}
+
+func (c *guardCompo) OnBeforeLeave(ctx Context, n *Navigation) {
+	c.leaving = n
+	if c.cancelLeave {
+		n.Cancel()
+	}
+}
+
+func (c *guardCompo) OnBeforeEnter(ctx Context, n *Navigation) {
+	if c.cancelEnter {
+		n.Cancel()
+	}
+	if c.redirectEnter != "" {
+		n.Redirect(c.redirectEnter)
+	}
+}
+
+func (c *guardCompo) Render() UI {
+	return Div()
+}
+
+type loaderCompo struct {
+	Compo
+
+	User string
+}
+
+func (c *loaderCompo) OnLoad(ctx Context, n *Navigation) error {
+	id := ctx.Param("id")
+	if id == "error" {
+		return errors.New("loading user failed")
+	}
+	c.User = "user " + id
+	return nil
+}
+
+func (c *loaderCompo) Render() UI {
+	return Text(c.User)
+}
+
+type blockedStreamCompo struct {
+	Compo
+
+	release chan struct{}
+	loader  bool
+}
+
+func (c *blockedStreamCompo) OnLoad(ctx Context, n *Navigation) error {
+	if c.loader {
+		<-c.release
+	}
+	return nil
+}
+
+func (c *blockedStreamCompo) OnPreRender(ctx Context) {
+	ctx.Async(func() {
+		<-c.release
+	})
+}
+
+func (c *blockedStreamCompo) Render() UI {
+	return Div().Text("blocked")
+}
+
+type streamCompo struct {
+	Compo
+
+	loaded bool
+}
+
+func (c *streamCompo) OnPreRender(ctx Context) {
+	ctx.Async(func() {
+		time.Sleep(time.Millisecond * 10)
+		ctx.Dispatch(func(ctx Context) {
+			c.loaded = true
+		})
+	})
+}
+
+func (c *streamCompo) Render() UI {
+	if !c.loaded {
+		return Div().Text("loading")
+	}
+	return Div().Text("loaded")
+}
@@ -2219,6 +2219,11 @@ func writeInterface(w io.Writer, t tag) {
 		`, t.Name)
 	}
 
+	fmt.Fprintf(w, `
+		// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+		Key(v any) HTML%s
+	`, t.Name)
+
 	for _, a := range t.Attrs {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w)
@@ -2314,6 +2319,16 @@ func writeStruct(w io.Writer, t tag) {
 		)
 	}
 
+	fmt.Fprintf(w, `
+		func (e *html%s) Key(v any) HTML%s {
+			e.elemKey = v
+			return e
+		}
+		`,
+		t.Name,
+		t.Name,
+	)
+
 	for _, a := range t.Attrs {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w)
@@ -2569,6 +2584,7 @@ import (
 		fmt.Fprintln(f, `elem.setEvents(nil)`)
 		fmt.Fprintln(f, `elem.setParent(nil)`)
 		fmt.Fprintln(f, `elem.setBody(nil)`)
+		fmt.Fprintln(f, `elem.Key("foo")`)
 
 		for _, a := range t.Attrs {
 			fmt.Fprintf(f, `elem.%s(`, a.Name)
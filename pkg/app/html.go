@@ -19,6 +19,7 @@ type HTML interface {
 	SelfClosing() bool
 
 	depth() uint
+	key() any
 	attrs() attributes
 	setAttrs(attributes) HTML
 	events() eventHandlers
@@ -34,6 +35,7 @@ type htmlElement struct {
 	tag           string
 	xmlns         string
 	treeDepth     uint
+	elemKey       any
 	isSelfClosing bool
 	jsElement     Value
 	attributes    attributes
@@ -66,6 +68,10 @@ func (e *htmlElement) depth() uint {
 	return e.treeDepth
 }
 
+func (e *htmlElement) key() any {
+	return e.elemKey
+}
+
 func (e *htmlElement) attrs() attributes {
 	return e.attributes
 }
@@ -19,6 +19,9 @@ type HTMLA interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLA
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLA
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLA
 
@@ -231,6 +234,11 @@ func (e *htmlA) Textf(format string, v ...any) HTMLA {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlA) Key(v any) HTMLA {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlA) AccessKey(format string, v ...any) HTMLA {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -554,6 +562,9 @@ type HTMLAbbr interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLAbbr
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLAbbr
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLAbbr
 
@@ -742,6 +753,11 @@ func (e *htmlAbbr) Textf(format string, v ...any) HTMLAbbr {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlAbbr) Key(v any) HTMLAbbr {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlAbbr) AccessKey(format string, v ...any) HTMLAbbr {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -1025,6 +1041,9 @@ type HTMLAddress interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLAddress
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLAddress
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLAddress
 
@@ -1213,6 +1232,11 @@ func (e *htmlAddress) Textf(format string, v ...any) HTMLAddress {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlAddress) Key(v any) HTMLAddress {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlAddress) AccessKey(format string, v ...any) HTMLAddress {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -1487,6 +1511,9 @@ func (e *htmlAddress) setBody(v []UI) HTML {
 type HTMLArea interface {
 	HTML
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLArea
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLArea
 
@@ -1693,6 +1720,11 @@ type htmlArea struct {
 	htmlElement
 }
 
+func (e *htmlArea) Key(v any) HTMLArea {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlArea) AccessKey(format string, v ...any) HTMLArea {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -2026,6 +2058,9 @@ type HTMLArticle interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLArticle
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLArticle
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLArticle
 
@@ -2214,6 +2249,11 @@ func (e *htmlArticle) Textf(format string, v ...any) HTMLArticle {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlArticle) Key(v any) HTMLArticle {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlArticle) AccessKey(format string, v ...any) HTMLArticle {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -2497,6 +2537,9 @@ type HTMLAside interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLAside
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLAside
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLAside
 
@@ -2685,6 +2728,11 @@ func (e *htmlAside) Textf(format string, v ...any) HTMLAside {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlAside) Key(v any) HTMLAside {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlAside) AccessKey(format string, v ...any) HTMLAside {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -2968,6 +3016,9 @@ type HTMLAudio interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLAudio
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLAudio
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLAudio
 
@@ -3246,6 +3297,11 @@ func (e *htmlAudio) Textf(format string, v ...any) HTMLAudio {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlAudio) Key(v any) HTMLAudio {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlAudio) AccessKey(format string, v ...any) HTMLAudio {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -3656,6 +3712,9 @@ type HTMLB interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLB
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLB
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLB
 
@@ -3844,6 +3903,11 @@ func (e *htmlB) Textf(format string, v ...any) HTMLB {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlB) Key(v any) HTMLB {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlB) AccessKey(format string, v ...any) HTMLB {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -4118,6 +4182,9 @@ func (e *htmlB) setBody(v []UI) HTML {
 type HTMLBase interface {
 	HTML
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLBase
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLBase
 
@@ -4300,6 +4367,11 @@ type htmlBase struct {
 	htmlElement
 }
 
+func (e *htmlBase) Key(v any) HTMLBase {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlBase) AccessKey(format string, v ...any) HTMLBase {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -4593,6 +4665,9 @@ type HTMLBdi interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLBdi
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLBdi
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLBdi
 
@@ -4781,6 +4856,11 @@ func (e *htmlBdi) Textf(format string, v ...any) HTMLBdi {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlBdi) Key(v any) HTMLBdi {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlBdi) AccessKey(format string, v ...any) HTMLBdi {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -5064,6 +5144,9 @@ type HTMLBdo interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLBdo
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLBdo
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLBdo
 
@@ -5252,6 +5335,11 @@ func (e *htmlBdo) Textf(format string, v ...any) HTMLBdo {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlBdo) Key(v any) HTMLBdo {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlBdo) AccessKey(format string, v ...any) HTMLBdo {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -5535,6 +5623,9 @@ type HTMLBlockquote interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLBlockquote
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLBlockquote
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLBlockquote
 
@@ -5726,6 +5817,11 @@ func (e *htmlBlockquote) Textf(format string, v ...any) HTMLBlockquote {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlBlockquote) Key(v any) HTMLBlockquote {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlBlockquote) AccessKey(format string, v ...any) HTMLBlockquote {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -6007,6 +6103,9 @@ type HTMLBody interface {
 
 	privateBody(elems ...UI) HTMLBody
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLBody
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLBody
 
@@ -6232,6 +6331,11 @@ func (e *htmlBody) privateBody(v ...UI) HTMLBody {
 	return e.setBody(FilterUIElems(v...)).(*htmlBody)
 }
 
+func (e *htmlBody) Key(v any) HTMLBody {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlBody) AccessKey(format string, v ...any) HTMLBody {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -6566,6 +6670,9 @@ func (e *htmlBody) setBody(v []UI) HTML {
 type HTMLBr interface {
 	HTML
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLBr
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLBr
 
@@ -6742,6 +6849,11 @@ type htmlBr struct {
 	htmlElement
 }
 
+func (e *htmlBr) Key(v any) HTMLBr {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlBr) AccessKey(format string, v ...any) HTMLBr {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -7025,6 +7137,9 @@ type HTMLButton interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLButton
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLButton
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLButton
 
@@ -7246,6 +7361,11 @@ func (e *htmlButton) Textf(format string, v ...any) HTMLButton {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlButton) Key(v any) HTMLButton {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlButton) AccessKey(format string, v ...any) HTMLButton {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -7584,6 +7704,9 @@ type HTMLCanvas interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLCanvas
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLCanvas
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLCanvas
 
@@ -7778,6 +7901,11 @@ func (e *htmlCanvas) Textf(format string, v ...any) HTMLCanvas {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlCanvas) Key(v any) HTMLCanvas {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlCanvas) AccessKey(format string, v ...any) HTMLCanvas {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -8071,6 +8199,9 @@ type HTMLCaption interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLCaption
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLCaption
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLCaption
 
@@ -8259,6 +8390,11 @@ func (e *htmlCaption) Textf(format string, v ...any) HTMLCaption {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlCaption) Key(v any) HTMLCaption {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlCaption) AccessKey(format string, v ...any) HTMLCaption {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -8542,6 +8678,9 @@ type HTMLCite interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLCite
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLCite
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLCite
 
@@ -8730,6 +8869,11 @@ func (e *htmlCite) Textf(format string, v ...any) HTMLCite {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlCite) Key(v any) HTMLCite {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlCite) AccessKey(format string, v ...any) HTMLCite {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -9013,6 +9157,9 @@ type HTMLCode interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLCode
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLCode
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLCode
 
@@ -9201,6 +9348,11 @@ func (e *htmlCode) Textf(format string, v ...any) HTMLCode {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlCode) Key(v any) HTMLCode {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlCode) AccessKey(format string, v ...any) HTMLCode {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -9475,6 +9627,9 @@ func (e *htmlCode) setBody(v []UI) HTML {
 type HTMLCol interface {
 	HTML
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLCol
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLCol
 
@@ -9654,6 +9809,11 @@ type htmlCol struct {
 	htmlElement
 }
 
+func (e *htmlCol) Key(v any) HTMLCol {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlCol) AccessKey(format string, v ...any) HTMLCol {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -9942,6 +10102,9 @@ type HTMLColGroup interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLColGroup
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLColGroup
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLColGroup
 
@@ -10133,6 +10296,11 @@ func (e *htmlColGroup) Textf(format string, v ...any) HTMLColGroup {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlColGroup) Key(v any) HTMLColGroup {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlColGroup) AccessKey(format string, v ...any) HTMLColGroup {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -10421,6 +10589,9 @@ type HTMLData interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLData
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLData
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLData
 
@@ -10510,6 +10681,11 @@ func (e *htmlData) Textf(format string, v ...any) HTMLData {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlData) Key(v any) HTMLData {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlData) AccessKey(format string, v ...any) HTMLData {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -10662,6 +10838,9 @@ type HTMLDataList interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLDataList
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLDataList
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLDataList
 
@@ -10850,6 +11029,11 @@ func (e *htmlDataList) Textf(format string, v ...any) HTMLDataList {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDataList) Key(v any) HTMLDataList {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlDataList) AccessKey(format string, v ...any) HTMLDataList {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -11133,6 +11317,9 @@ type HTMLDd interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLDd
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLDd
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLDd
 
@@ -11321,6 +11508,11 @@ func (e *htmlDd) Textf(format string, v ...any) HTMLDd {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDd) Key(v any) HTMLDd {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlDd) AccessKey(format string, v ...any) HTMLDd {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -11604,6 +11796,9 @@ type HTMLDel interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLDel
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLDel
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLDel
 
@@ -11798,6 +11993,11 @@ func (e *htmlDel) Textf(format string, v ...any) HTMLDel {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDel) Key(v any) HTMLDel {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlDel) AccessKey(format string, v ...any) HTMLDel {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -12091,6 +12291,9 @@ type HTMLDetails interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLDetails
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLDetails
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLDetails
 
@@ -12285,6 +12488,11 @@ func (e *htmlDetails) Textf(format string, v ...any) HTMLDetails {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDetails) Key(v any) HTMLDetails {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlDetails) AccessKey(format string, v ...any) HTMLDetails {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -12577,6 +12785,9 @@ type HTMLDfn interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLDfn
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLDfn
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLDfn
 
@@ -12765,6 +12976,11 @@ func (e *htmlDfn) Textf(format string, v ...any) HTMLDfn {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDfn) Key(v any) HTMLDfn {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlDfn) AccessKey(format string, v ...any) HTMLDfn {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -13048,6 +13264,9 @@ type HTMLDialog interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLDialog
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLDialog
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLDialog
 
@@ -13239,6 +13458,11 @@ func (e *htmlDialog) Textf(format string, v ...any) HTMLDialog {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDialog) Key(v any) HTMLDialog {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlDialog) AccessKey(format string, v ...any) HTMLDialog {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -13527,6 +13751,9 @@ type HTMLDiv interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLDiv
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLDiv
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLDiv
 
@@ -13715,6 +13942,11 @@ func (e *htmlDiv) Textf(format string, v ...any) HTMLDiv {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDiv) Key(v any) HTMLDiv {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlDiv) AccessKey(format string, v ...any) HTMLDiv {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -13998,6 +14230,9 @@ type HTMLDl interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLDl
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLDl
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLDl
 
@@ -14186,6 +14421,11 @@ func (e *htmlDl) Textf(format string, v ...any) HTMLDl {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDl) Key(v any) HTMLDl {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlDl) AccessKey(format string, v ...any) HTMLDl {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -14469,6 +14709,9 @@ type HTMLDt interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLDt
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLDt
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLDt
 
@@ -14657,6 +14900,11 @@ func (e *htmlDt) Textf(format string, v ...any) HTMLDt {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDt) Key(v any) HTMLDt {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlDt) AccessKey(format string, v ...any) HTMLDt {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -14940,6 +15188,9 @@ type HTMLElem interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLElem
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLElem
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLElem
 
@@ -15131,6 +15382,11 @@ func (e *htmlElem) Textf(format string, v ...any) HTMLElem {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlElem) Key(v any) HTMLElem {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlElem) AccessKey(format string, v ...any) HTMLElem {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -15410,6 +15666,9 @@ func (e *htmlElem) setBody(v []UI) HTML {
 type HTMLElemSelfClosing interface {
 	HTML
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLElemSelfClosing
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLElemSelfClosing
 
@@ -15589,6 +15848,11 @@ type htmlElemSelfClosing struct {
 	htmlElement
 }
 
+func (e *htmlElemSelfClosing) Key(v any) HTMLElemSelfClosing {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlElemSelfClosing) AccessKey(format string, v ...any) HTMLElemSelfClosing {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -15877,6 +16141,9 @@ type HTMLEm interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLEm
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLEm
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLEm
 
@@ -16065,6 +16332,11 @@ func (e *htmlEm) Textf(format string, v ...any) HTMLEm {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlEm) Key(v any) HTMLEm {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlEm) AccessKey(format string, v ...any) HTMLEm {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -16339,6 +16611,9 @@ func (e *htmlEm) setBody(v []UI) HTML {
 type HTMLEmbed interface {
 	HTML
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLEmbed
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLEmbed
 
@@ -16596,6 +16871,11 @@ type htmlEmbed struct {
 	htmlElement
 }
 
+func (e *htmlEmbed) Key(v any) HTMLEmbed {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlEmbed) AccessKey(format string, v ...any) HTMLEmbed {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -16991,6 +17271,9 @@ type HTMLFieldSet interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLFieldSet
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLFieldSet
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLFieldSet
 
@@ -17188,6 +17471,11 @@ func (e *htmlFieldSet) Textf(format string, v ...any) HTMLFieldSet {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlFieldSet) Key(v any) HTMLFieldSet {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlFieldSet) AccessKey(format string, v ...any) HTMLFieldSet {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -17486,6 +17774,9 @@ type HTMLFigCaption interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLFigCaption
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLFigCaption
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLFigCaption
 
@@ -17674,6 +17965,11 @@ func (e *htmlFigCaption) Textf(format string, v ...any) HTMLFigCaption {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlFigCaption) Key(v any) HTMLFigCaption {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlFigCaption) AccessKey(format string, v ...any) HTMLFigCaption {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -17957,6 +18253,9 @@ type HTMLFigure interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLFigure
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLFigure
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLFigure
 
@@ -18145,6 +18444,11 @@ func (e *htmlFigure) Textf(format string, v ...any) HTMLFigure {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlFigure) Key(v any) HTMLFigure {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlFigure) AccessKey(format string, v ...any) HTMLFigure {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -18428,6 +18732,9 @@ type HTMLFooter interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLFooter
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLFooter
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLFooter
 
@@ -18616,6 +18923,11 @@ func (e *htmlFooter) Textf(format string, v ...any) HTMLFooter {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlFooter) Key(v any) HTMLFooter {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlFooter) AccessKey(format string, v ...any) HTMLFooter {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -18899,6 +19211,9 @@ type HTMLForm interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLForm
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLForm
+
 	// Restricts the character encodings accepted for form submission, ensuring compatibility.
 	AcceptCharset(format string, v ...any) HTMLForm
 
@@ -19111,6 +19426,11 @@ func (e *htmlForm) Textf(format string, v ...any) HTMLForm {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlForm) Key(v any) HTMLForm {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlForm) AcceptCharset(format string, v ...any) HTMLForm {
 	e.setAttr("accept-charset", FormatString(format, v...))
 	return e
@@ -19439,6 +19759,9 @@ type HTMLH1 interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLH1
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLH1
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLH1
 
@@ -19627,6 +19950,11 @@ func (e *htmlH1) Textf(format string, v ...any) HTMLH1 {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlH1) Key(v any) HTMLH1 {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlH1) AccessKey(format string, v ...any) HTMLH1 {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -19910,6 +20238,9 @@ type HTMLH2 interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLH2
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLH2
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLH2
 
@@ -20098,6 +20429,11 @@ func (e *htmlH2) Textf(format string, v ...any) HTMLH2 {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlH2) Key(v any) HTMLH2 {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlH2) AccessKey(format string, v ...any) HTMLH2 {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -20381,6 +20717,9 @@ type HTMLH3 interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLH3
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLH3
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLH3
 
@@ -20569,6 +20908,11 @@ func (e *htmlH3) Textf(format string, v ...any) HTMLH3 {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlH3) Key(v any) HTMLH3 {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlH3) AccessKey(format string, v ...any) HTMLH3 {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -20852,6 +21196,9 @@ type HTMLH4 interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLH4
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLH4
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLH4
 
@@ -21040,6 +21387,11 @@ func (e *htmlH4) Textf(format string, v ...any) HTMLH4 {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlH4) Key(v any) HTMLH4 {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlH4) AccessKey(format string, v ...any) HTMLH4 {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -21323,6 +21675,9 @@ type HTMLH5 interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLH5
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLH5
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLH5
 
@@ -21511,6 +21866,11 @@ func (e *htmlH5) Textf(format string, v ...any) HTMLH5 {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlH5) Key(v any) HTMLH5 {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlH5) AccessKey(format string, v ...any) HTMLH5 {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -21794,6 +22154,9 @@ type HTMLH6 interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLH6
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLH6
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLH6
 
@@ -21982,6 +22345,11 @@ func (e *htmlH6) Textf(format string, v ...any) HTMLH6 {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlH6) Key(v any) HTMLH6 {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlH6) AccessKey(format string, v ...any) HTMLH6 {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -22265,6 +22633,9 @@ type HTMLHead interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLHead
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLHead
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLHead
 
@@ -22351,6 +22722,11 @@ func (e *htmlHead) Textf(format string, v ...any) HTMLHead {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlHead) Key(v any) HTMLHead {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlHead) AccessKey(format string, v ...any) HTMLHead {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -22498,6 +22874,9 @@ type HTMLHeader interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLHeader
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLHeader
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLHeader
 
@@ -22686,6 +23065,11 @@ func (e *htmlHeader) Textf(format string, v ...any) HTMLHeader {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlHeader) Key(v any) HTMLHeader {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlHeader) AccessKey(format string, v ...any) HTMLHeader {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -22960,6 +23344,9 @@ func (e *htmlHeader) setBody(v []UI) HTML {
 type HTMLHr interface {
 	HTML
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLHr
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLHr
 
@@ -23136,6 +23523,11 @@ type htmlHr struct {
 	htmlElement
 }
 
+func (e *htmlHr) Key(v any) HTMLHr {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlHr) AccessKey(format string, v ...any) HTMLHr {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -23412,6 +23804,9 @@ type HTMLHtml interface {
 
 	privateBody(elems ...UI) HTMLHtml
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLHtml
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLHtml
 
@@ -23490,6 +23885,11 @@ func (e *htmlHtml) privateBody(v ...UI) HTMLHtml {
 	return e.setBody(FilterUIElems(v...)).(*htmlHtml)
 }
 
+func (e *htmlHtml) Key(v any) HTMLHtml {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlHtml) AccessKey(format string, v ...any) HTMLHtml {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -23637,6 +24037,9 @@ type HTMLI interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLI
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLI
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLI
 
@@ -23825,6 +24228,11 @@ func (e *htmlI) Textf(format string, v ...any) HTMLI {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlI) Key(v any) HTMLI {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlI) AccessKey(format string, v ...any) HTMLI {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -24108,6 +24516,9 @@ type HTMLIFrame interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLIFrame
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLIFrame
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLIFrame
 
@@ -24332,6 +24743,11 @@ func (e *htmlIFrame) Textf(format string, v ...any) HTMLIFrame {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlIFrame) Key(v any) HTMLIFrame {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlIFrame) AccessKey(format string, v ...any) HTMLIFrame {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -24675,6 +25091,9 @@ func (e *htmlIFrame) setBody(v []UI) HTML {
 type HTMLImg interface {
 	HTML
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLImg
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLImg
 
@@ -24956,6 +25375,11 @@ type htmlImg struct {
 	htmlElement
 }
 
+func (e *htmlImg) Key(v any) HTMLImg {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlImg) AccessKey(format string, v ...any) HTMLImg {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -25381,6 +25805,9 @@ func (e *htmlImg) setBody(v []UI) HTML {
 type HTMLInput interface {
 	HTML
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLInput
+
 	// Restricts file types the server accepts, especially used for file input elements.
 	Accept(format string, v ...any) HTMLInput
 
@@ -25653,6 +26080,11 @@ type htmlInput struct {
 	htmlElement
 }
 
+func (e *htmlInput) Key(v any) HTMLInput {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlInput) Accept(format string, v ...any) HTMLInput {
 	e.setAttr("accept", FormatString(format, v...))
 	return e
@@ -26100,6 +26532,9 @@ type HTMLIns interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLIns
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLIns
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLIns
 
@@ -26288,6 +26723,11 @@ func (e *htmlIns) Textf(format string, v ...any) HTMLIns {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlIns) Key(v any) HTMLIns {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlIns) AccessKey(format string, v ...any) HTMLIns {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -26571,6 +27011,9 @@ type HTMLKbd interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLKbd
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLKbd
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLKbd
 
@@ -26759,6 +27202,11 @@ func (e *htmlKbd) Textf(format string, v ...any) HTMLKbd {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlKbd) Key(v any) HTMLKbd {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlKbd) AccessKey(format string, v ...any) HTMLKbd {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -27042,6 +27490,9 @@ type HTMLLabel interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLLabel
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLLabel
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLLabel
 
@@ -27236,6 +27687,11 @@ func (e *htmlLabel) Textf(format string, v ...any) HTMLLabel {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlLabel) Key(v any) HTMLLabel {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlLabel) AccessKey(format string, v ...any) HTMLLabel {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -27529,6 +27985,9 @@ type HTMLLegend interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLLegend
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLLegend
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLLegend
 
@@ -27717,6 +28176,11 @@ func (e *htmlLegend) Textf(format string, v ...any) HTMLLegend {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlLegend) Key(v any) HTMLLegend {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlLegend) AccessKey(format string, v ...any) HTMLLegend {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -28000,6 +28464,9 @@ type HTMLLi interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLLi
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLLi
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLLi
 
@@ -28191,6 +28658,11 @@ func (e *htmlLi) Textf(format string, v ...any) HTMLLi {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlLi) Key(v any) HTMLLi {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlLi) AccessKey(format string, v ...any) HTMLLi {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -28470,6 +28942,9 @@ func (e *htmlLi) setBody(v []UI) HTML {
 type HTMLLink interface {
 	HTML
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLLink
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLLink
 
@@ -28676,6 +29151,11 @@ type htmlLink struct {
 	htmlElement
 }
 
+func (e *htmlLink) Key(v any) HTMLLink {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlLink) AccessKey(format string, v ...any) HTMLLink {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -29008,6 +29488,9 @@ type HTMLMain interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLMain
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLMain
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLMain
 
@@ -29196,6 +29679,11 @@ func (e *htmlMain) Textf(format string, v ...any) HTMLMain {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlMain) Key(v any) HTMLMain {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlMain) AccessKey(format string, v ...any) HTMLMain {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -29479,6 +29967,9 @@ type HTMLMap interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLMap
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLMap
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLMap
 
@@ -29670,6 +30161,11 @@ func (e *htmlMap) Textf(format string, v ...any) HTMLMap {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlMap) Key(v any) HTMLMap {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlMap) AccessKey(format string, v ...any) HTMLMap {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -29958,6 +30454,9 @@ type HTMLMark interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLMark
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLMark
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLMark
 
@@ -30146,6 +30645,11 @@ func (e *htmlMark) Textf(format string, v ...any) HTMLMark {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlMark) Key(v any) HTMLMark {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlMark) AccessKey(format string, v ...any) HTMLMark {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -30420,6 +30924,9 @@ func (e *htmlMark) setBody(v []UI) HTML {
 type HTMLMeta interface {
 	HTML
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLMeta
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLMeta
 
@@ -30509,6 +31016,11 @@ type htmlMeta struct {
 	htmlElement
 }
 
+func (e *htmlMeta) Key(v any) HTMLMeta {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlMeta) AccessKey(format string, v ...any) HTMLMeta {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -30681,6 +31193,9 @@ type HTMLMeter interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLMeter
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLMeter
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLMeter
 
@@ -30890,6 +31405,11 @@ func (e *htmlMeter) Textf(format string, v ...any) HTMLMeter {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlMeter) Key(v any) HTMLMeter {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlMeter) AccessKey(format string, v ...any) HTMLMeter {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -31208,6 +31728,9 @@ type HTMLNav interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLNav
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLNav
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLNav
 
@@ -31396,6 +31919,11 @@ func (e *htmlNav) Textf(format string, v ...any) HTMLNav {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlNav) Key(v any) HTMLNav {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlNav) AccessKey(format string, v ...any) HTMLNav {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -31679,6 +32207,9 @@ type HTMLNoScript interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLNoScript
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLNoScript
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLNoScript
 
@@ -31765,6 +32296,11 @@ func (e *htmlNoScript) Textf(format string, v ...any) HTMLNoScript {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlNoScript) Key(v any) HTMLNoScript {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlNoScript) AccessKey(format string, v ...any) HTMLNoScript {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -31912,6 +32448,9 @@ type HTMLObject interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLObject
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLObject
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLObject
 
@@ -32190,6 +32729,11 @@ func (e *htmlObject) Textf(format string, v ...any) HTMLObject {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlObject) Key(v any) HTMLObject {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlObject) AccessKey(format string, v ...any) HTMLObject {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -32600,6 +33144,9 @@ type HTMLOl interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLOl
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLOl
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLOl
 
@@ -32797,6 +33344,11 @@ func (e *htmlOl) Textf(format string, v ...any) HTMLOl {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlOl) Key(v any) HTMLOl {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlOl) AccessKey(format string, v ...any) HTMLOl {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -33095,6 +33647,9 @@ type HTMLOptGroup interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLOptGroup
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLOptGroup
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLOptGroup
 
@@ -33289,6 +33844,11 @@ func (e *htmlOptGroup) Textf(format string, v ...any) HTMLOptGroup {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlOptGroup) Key(v any) HTMLOptGroup {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlOptGroup) AccessKey(format string, v ...any) HTMLOptGroup {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -33582,6 +34142,9 @@ type HTMLOption interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLOption
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLOption
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLOption
 
@@ -33782,6 +34345,11 @@ func (e *htmlOption) Textf(format string, v ...any) HTMLOption {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlOption) Key(v any) HTMLOption {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlOption) AccessKey(format string, v ...any) HTMLOption {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -34085,6 +34653,9 @@ type HTMLOutput interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLOutput
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLOutput
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLOutput
 
@@ -34282,6 +34853,11 @@ func (e *htmlOutput) Textf(format string, v ...any) HTMLOutput {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlOutput) Key(v any) HTMLOutput {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlOutput) AccessKey(format string, v ...any) HTMLOutput {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -34580,6 +35156,9 @@ type HTMLP interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLP
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLP
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLP
 
@@ -34768,6 +35347,11 @@ func (e *htmlP) Textf(format string, v ...any) HTMLP {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlP) Key(v any) HTMLP {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlP) AccessKey(format string, v ...any) HTMLP {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -35042,6 +35626,9 @@ func (e *htmlP) setBody(v []UI) HTML {
 type HTMLParam interface {
 	HTML
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLParam
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLParam
 
@@ -35224,6 +35811,11 @@ type htmlParam struct {
 	htmlElement
 }
 
+func (e *htmlParam) Key(v any) HTMLParam {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlParam) AccessKey(format string, v ...any) HTMLParam {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -35517,6 +36109,9 @@ type HTMLPicture interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLPicture
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLPicture
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLPicture
 
@@ -35705,6 +36300,11 @@ func (e *htmlPicture) Textf(format string, v ...any) HTMLPicture {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlPicture) Key(v any) HTMLPicture {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlPicture) AccessKey(format string, v ...any) HTMLPicture {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -35988,6 +36588,9 @@ type HTMLPre interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLPre
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLPre
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLPre
 
@@ -36176,6 +36779,11 @@ func (e *htmlPre) Textf(format string, v ...any) HTMLPre {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlPre) Key(v any) HTMLPre {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlPre) AccessKey(format string, v ...any) HTMLPre {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -36459,6 +37067,9 @@ type HTMLProgress interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLProgress
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLProgress
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLProgress
 
@@ -36653,6 +37264,11 @@ func (e *htmlProgress) Textf(format string, v ...any) HTMLProgress {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlProgress) Key(v any) HTMLProgress {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlProgress) AccessKey(format string, v ...any) HTMLProgress {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -36946,6 +37562,9 @@ type HTMLQ interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLQ
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLQ
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLQ
 
@@ -37137,6 +37756,11 @@ func (e *htmlQ) Textf(format string, v ...any) HTMLQ {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlQ) Key(v any) HTMLQ {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlQ) AccessKey(format string, v ...any) HTMLQ {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -37425,6 +38049,9 @@ type HTMLRp interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLRp
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLRp
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLRp
 
@@ -37613,6 +38240,11 @@ func (e *htmlRp) Textf(format string, v ...any) HTMLRp {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlRp) Key(v any) HTMLRp {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlRp) AccessKey(format string, v ...any) HTMLRp {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -37896,6 +38528,9 @@ type HTMLRt interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLRt
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLRt
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLRt
 
@@ -38084,6 +38719,11 @@ func (e *htmlRt) Textf(format string, v ...any) HTMLRt {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlRt) Key(v any) HTMLRt {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlRt) AccessKey(format string, v ...any) HTMLRt {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -38367,6 +39007,9 @@ type HTMLRuby interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLRuby
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLRuby
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLRuby
 
@@ -38555,6 +39198,11 @@ func (e *htmlRuby) Textf(format string, v ...any) HTMLRuby {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlRuby) Key(v any) HTMLRuby {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlRuby) AccessKey(format string, v ...any) HTMLRuby {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -38838,6 +39486,9 @@ type HTMLS interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLS
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLS
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLS
 
@@ -39026,6 +39677,11 @@ func (e *htmlS) Textf(format string, v ...any) HTMLS {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlS) Key(v any) HTMLS {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlS) AccessKey(format string, v ...any) HTMLS {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -39309,6 +39965,9 @@ type HTMLSamp interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLSamp
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLSamp
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLSamp
 
@@ -39497,6 +40156,11 @@ func (e *htmlSamp) Textf(format string, v ...any) HTMLSamp {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSamp) Key(v any) HTMLSamp {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlSamp) AccessKey(format string, v ...any) HTMLSamp {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -39780,6 +40444,9 @@ type HTMLScript interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLScript
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLScript
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLScript
 
@@ -39887,6 +40554,11 @@ func (e *htmlScript) Textf(format string, v ...any) HTMLScript {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlScript) Key(v any) HTMLScript {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlScript) AccessKey(format string, v ...any) HTMLScript {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -40068,6 +40740,9 @@ type HTMLSection interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLSection
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLSection
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLSection
 
@@ -40256,6 +40931,11 @@ func (e *htmlSection) Textf(format string, v ...any) HTMLSection {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSection) Key(v any) HTMLSection {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlSection) AccessKey(format string, v ...any) HTMLSection {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -40539,6 +41219,9 @@ type HTMLSelect interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLSelect
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLSelect
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLSelect
 
@@ -40748,6 +41431,11 @@ func (e *htmlSelect) Textf(format string, v ...any) HTMLSelect {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSelect) Key(v any) HTMLSelect {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlSelect) AccessKey(format string, v ...any) HTMLSelect {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -41066,6 +41754,9 @@ type HTMLSmall interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLSmall
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLSmall
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLSmall
 
@@ -41254,6 +41945,11 @@ func (e *htmlSmall) Textf(format string, v ...any) HTMLSmall {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSmall) Key(v any) HTMLSmall {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlSmall) AccessKey(format string, v ...any) HTMLSmall {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -41528,6 +42224,9 @@ func (e *htmlSmall) setBody(v []UI) HTML {
 type HTMLSource interface {
 	HTML
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLSource
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLSource
 
@@ -41719,6 +42418,11 @@ type htmlSource struct {
 	htmlElement
 }
 
+func (e *htmlSource) Key(v any) HTMLSource {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlSource) AccessKey(format string, v ...any) HTMLSource {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -42027,6 +42731,9 @@ type HTMLSpan interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLSpan
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLSpan
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLSpan
 
@@ -42215,6 +42922,11 @@ func (e *htmlSpan) Textf(format string, v ...any) HTMLSpan {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSpan) Key(v any) HTMLSpan {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlSpan) AccessKey(format string, v ...any) HTMLSpan {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -42498,6 +43210,9 @@ type HTMLStrong interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLStrong
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLStrong
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLStrong
 
@@ -42686,6 +43401,11 @@ func (e *htmlStrong) Textf(format string, v ...any) HTMLStrong {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlStrong) Key(v any) HTMLStrong {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlStrong) AccessKey(format string, v ...any) HTMLStrong {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -42969,6 +43689,9 @@ type HTMLStyle interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLStyle
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLStyle
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLStyle
 
@@ -43166,6 +43889,11 @@ func (e *htmlStyle) Textf(format string, v ...any) HTMLStyle {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlStyle) Key(v any) HTMLStyle {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlStyle) AccessKey(format string, v ...any) HTMLStyle {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -43463,6 +44191,9 @@ type HTMLSub interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLSub
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLSub
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLSub
 
@@ -43651,6 +44382,11 @@ func (e *htmlSub) Textf(format string, v ...any) HTMLSub {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSub) Key(v any) HTMLSub {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlSub) AccessKey(format string, v ...any) HTMLSub {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -43934,6 +44670,9 @@ type HTMLSummary interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLSummary
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLSummary
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLSummary
 
@@ -44122,6 +44861,11 @@ func (e *htmlSummary) Textf(format string, v ...any) HTMLSummary {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSummary) Key(v any) HTMLSummary {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlSummary) AccessKey(format string, v ...any) HTMLSummary {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -44405,6 +45149,9 @@ type HTMLSup interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLSup
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLSup
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLSup
 
@@ -44593,6 +45340,11 @@ func (e *htmlSup) Textf(format string, v ...any) HTMLSup {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSup) Key(v any) HTMLSup {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlSup) AccessKey(format string, v ...any) HTMLSup {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -44876,6 +45628,9 @@ type HTMLTable interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLTable
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLTable
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLTable
 
@@ -45064,6 +45819,11 @@ func (e *htmlTable) Textf(format string, v ...any) HTMLTable {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTable) Key(v any) HTMLTable {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlTable) AccessKey(format string, v ...any) HTMLTable {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -45347,6 +46107,9 @@ type HTMLTBody interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLTBody
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLTBody
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLTBody
 
@@ -45535,6 +46298,11 @@ func (e *htmlTBody) Textf(format string, v ...any) HTMLTBody {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTBody) Key(v any) HTMLTBody {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlTBody) AccessKey(format string, v ...any) HTMLTBody {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -45818,6 +46586,9 @@ type HTMLTd interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLTd
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLTd
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLTd
 
@@ -46015,6 +46786,11 @@ func (e *htmlTd) Textf(format string, v ...any) HTMLTd {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTd) Key(v any) HTMLTd {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlTd) AccessKey(format string, v ...any) HTMLTd {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -46313,6 +47089,9 @@ type HTMLTemplate interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLTemplate
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLTemplate
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLTemplate
 
@@ -46399,6 +47178,11 @@ func (e *htmlTemplate) Textf(format string, v ...any) HTMLTemplate {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTemplate) Key(v any) HTMLTemplate {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlTemplate) AccessKey(format string, v ...any) HTMLTemplate {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -46546,6 +47330,9 @@ type HTMLTextarea interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLTextarea
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLTextarea
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLTextarea
 
@@ -46772,6 +47559,11 @@ func (e *htmlTextarea) Textf(format string, v ...any) HTMLTextarea {
 	return e
 }
 
+func (e *htmlTextarea) Key(v any) HTMLTextarea {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlTextarea) AccessKey(format string, v ...any) HTMLTextarea {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -47115,6 +47907,9 @@ type HTMLTFoot interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLTFoot
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLTFoot
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLTFoot
 
@@ -47303,6 +48098,11 @@ func (e *htmlTFoot) Textf(format string, v ...any) HTMLTFoot {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTFoot) Key(v any) HTMLTFoot {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlTFoot) AccessKey(format string, v ...any) HTMLTFoot {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -47586,6 +48386,9 @@ type HTMLTh interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLTh
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLTh
+
 	// Denotes abbreviated content for header cells to provide clarity on shortened terms.
 	Abbr(format string, v ...any) HTMLTh
 
@@ -47789,6 +48592,11 @@ func (e *htmlTh) Textf(format string, v ...any) HTMLTh {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTh) Key(v any) HTMLTh {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlTh) Abbr(format string, v ...any) HTMLTh {
 	e.setAttr("abbr", FormatString(format, v...))
 	return e
@@ -48097,6 +48905,9 @@ type HTMLTHead interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLTHead
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLTHead
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLTHead
 
@@ -48285,6 +49096,11 @@ func (e *htmlTHead) Textf(format string, v ...any) HTMLTHead {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTHead) Key(v any) HTMLTHead {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlTHead) AccessKey(format string, v ...any) HTMLTHead {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -48568,6 +49384,9 @@ type HTMLTime interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLTime
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLTime
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLTime
 
@@ -48759,6 +49578,11 @@ func (e *htmlTime) Textf(format string, v ...any) HTMLTime {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTime) Key(v any) HTMLTime {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlTime) AccessKey(format string, v ...any) HTMLTime {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -49047,6 +49871,9 @@ type HTMLTitle interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLTitle
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLTitle
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLTitle
 
@@ -49133,6 +49960,11 @@ func (e *htmlTitle) Textf(format string, v ...any) HTMLTitle {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTitle) Key(v any) HTMLTitle {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlTitle) AccessKey(format string, v ...any) HTMLTitle {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -49280,6 +50112,9 @@ type HTMLTr interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLTr
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLTr
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLTr
 
@@ -49468,6 +50303,11 @@ func (e *htmlTr) Textf(format string, v ...any) HTMLTr {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTr) Key(v any) HTMLTr {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlTr) AccessKey(format string, v ...any) HTMLTr {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -49751,6 +50591,9 @@ type HTMLU interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLU
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLU
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLU
 
@@ -49939,6 +50782,11 @@ func (e *htmlU) Textf(format string, v ...any) HTMLU {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlU) Key(v any) HTMLU {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlU) AccessKey(format string, v ...any) HTMLU {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -50222,6 +51070,9 @@ type HTMLUl interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLUl
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLUl
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLUl
 
@@ -50410,6 +51261,11 @@ func (e *htmlUl) Textf(format string, v ...any) HTMLUl {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlUl) Key(v any) HTMLUl {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlUl) AccessKey(format string, v ...any) HTMLUl {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -50693,6 +51549,9 @@ type HTMLVar interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLVar
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLVar
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLVar
 
@@ -50881,6 +51740,11 @@ func (e *htmlVar) Textf(format string, v ...any) HTMLVar {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlVar) Key(v any) HTMLVar {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlVar) AccessKey(format string, v ...any) HTMLVar {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -51164,6 +52028,9 @@ type HTMLVideo interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLVideo
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLVideo
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLVideo
 
@@ -51451,6 +52318,11 @@ func (e *htmlVideo) Textf(format string, v ...any) HTMLVideo {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlVideo) Key(v any) HTMLVideo {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlVideo) AccessKey(format string, v ...any) HTMLVideo {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -51876,6 +52748,9 @@ type HTMLWbr interface {
 	// Sets the content of the element with a text node formatted according to a format specifier.
 	Textf(format string, v ...any) HTMLWbr
 
+	// Identifies the element among its siblings with a comparable value, allowing it to be matched, moved, or removed without being re-created when its parent is updated.
+	Key(v any) HTMLWbr
+
 	// Assigns a keyboard shortcut for quick element activation or focus, enhancing user experience.
 	AccessKey(format string, v ...any) HTMLWbr
 
@@ -52064,6 +52939,11 @@ func (e *htmlWbr) Textf(format string, v ...any) HTMLWbr {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlWbr) Key(v any) HTMLWbr {
+	e.elemKey = v
+	return e
+}
+
 func (e *htmlWbr) AccessKey(format string, v ...any) HTMLWbr {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -14,6 +14,7 @@ func TestA(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -93,6 +94,7 @@ func TestAbbr(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -164,6 +166,7 @@ func TestAddress(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -235,6 +238,7 @@ func TestArea(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Alt("hello %v", 42)
 	elem.Aria("foo", "bar")
@@ -314,6 +318,7 @@ func TestArticle(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -385,6 +390,7 @@ func TestAside(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -456,6 +462,7 @@ func TestAudio(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -561,6 +568,7 @@ func TestB(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -632,6 +640,7 @@ func TestBase(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -703,6 +712,7 @@ func TestBdi(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -774,6 +784,7 @@ func TestBdo(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -845,6 +856,7 @@ func TestBlockquote(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -917,6 +929,7 @@ func TestBody(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -1002,6 +1015,7 @@ func TestBr(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -1071,6 +1085,7 @@ func TestButton(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -1156,6 +1171,7 @@ func TestCanvas(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -1229,6 +1245,7 @@ func TestCaption(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -1300,6 +1317,7 @@ func TestCite(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -1371,6 +1389,7 @@ func TestCode(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -1442,6 +1461,7 @@ func TestCol(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -1512,6 +1532,7 @@ func TestColGroup(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -1584,6 +1605,7 @@ func TestData(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -1622,6 +1644,7 @@ func TestDataList(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -1693,6 +1716,7 @@ func TestDd(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -1764,6 +1788,7 @@ func TestDel(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -1837,6 +1862,7 @@ func TestDetails(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -1911,6 +1937,7 @@ func TestDfn(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -1982,6 +2009,7 @@ func TestDialog(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -2055,6 +2083,7 @@ func TestDiv(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -2126,6 +2155,7 @@ func TestDl(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -2197,6 +2227,7 @@ func TestDt(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -2268,6 +2299,7 @@ func TestElem(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -2340,6 +2372,7 @@ func TestElemSelfClosing(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -2410,6 +2443,7 @@ func TestEm(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -2481,6 +2515,7 @@ func TestEmbed(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -2577,6 +2612,7 @@ func TestFieldSet(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -2652,6 +2688,7 @@ func TestFigCaption(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -2723,6 +2760,7 @@ func TestFigure(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -2794,6 +2832,7 @@ func TestFooter(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -2865,6 +2904,7 @@ func TestForm(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AcceptCharset("hello %v", 42)
 	elem.AccessKey("hello %v", 42)
 	elem.Action("hello %v", 42)
@@ -2946,6 +2986,7 @@ func TestH1(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -3017,6 +3058,7 @@ func TestH2(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -3088,6 +3130,7 @@ func TestH3(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -3159,6 +3202,7 @@ func TestH4(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -3230,6 +3274,7 @@ func TestH5(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -3301,6 +3346,7 @@ func TestH6(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -3372,6 +3418,7 @@ func TestHead(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -3409,6 +3456,7 @@ func TestHeader(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -3480,6 +3528,7 @@ func TestHr(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -3549,6 +3598,7 @@ func TestHtml(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -3585,6 +3635,7 @@ func TestI(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -3656,6 +3707,7 @@ func TestIFrame(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Allow("hello %v", 42)
 	elem.AllowFullscreen(true)
@@ -3741,6 +3793,7 @@ func TestImg(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Alt("hello %v", 42)
 	elem.Aria("foo", "bar")
@@ -3846,6 +3899,7 @@ func TestInput(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.Accept("hello %v", 42)
 	elem.AccessKey("hello %v", 42)
 	elem.Alt("hello %v", 42)
@@ -3955,6 +4009,7 @@ func TestIns(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -4026,6 +4081,7 @@ func TestKbd(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -4097,6 +4153,7 @@ func TestLabel(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -4170,6 +4227,7 @@ func TestLegend(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -4241,6 +4299,7 @@ func TestLi(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -4313,6 +4372,7 @@ func TestLink(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.As("hello %v", 42)
@@ -4392,6 +4452,7 @@ func TestMain(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -4463,6 +4524,7 @@ func TestMap(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -4535,6 +4597,7 @@ func TestMark(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -4606,6 +4669,7 @@ func TestMeta(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -4646,6 +4710,7 @@ func TestMeter(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -4724,6 +4789,7 @@ func TestNav(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -4795,6 +4861,7 @@ func TestNoScript(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -4832,6 +4899,7 @@ func TestObject(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -4933,6 +5001,7 @@ func TestOl(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -5008,6 +5077,7 @@ func TestOptGroup(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -5082,6 +5152,7 @@ func TestOption(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -5159,6 +5230,7 @@ func TestOutput(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -5233,6 +5305,7 @@ func TestP(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -5304,6 +5377,7 @@ func TestParam(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -5375,6 +5449,7 @@ func TestPicture(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -5446,6 +5521,7 @@ func TestPre(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -5517,6 +5593,7 @@ func TestProgress(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -5590,6 +5667,7 @@ func TestQ(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -5662,6 +5740,7 @@ func TestRp(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -5733,6 +5812,7 @@ func TestRt(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -5804,6 +5884,7 @@ func TestRuby(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -5875,6 +5956,7 @@ func TestS(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -5946,6 +6028,7 @@ func TestSamp(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -6017,6 +6100,7 @@ func TestScript(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Async(true)
@@ -6063,6 +6147,7 @@ func TestSection(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -6134,6 +6219,7 @@ func TestSelect(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -6216,6 +6302,7 @@ func TestSmall(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -6287,6 +6374,7 @@ func TestSource(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -6361,6 +6449,7 @@ func TestSpan(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -6432,6 +6521,7 @@ func TestStrong(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -6503,6 +6593,7 @@ func TestStyle(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -6577,6 +6668,7 @@ func TestSub(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -6648,6 +6740,7 @@ func TestSummary(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -6719,6 +6812,7 @@ func TestSup(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -6790,6 +6884,7 @@ func TestTable(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -6861,6 +6956,7 @@ func TestTBody(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -6932,6 +7028,7 @@ func TestTd(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -7006,6 +7103,7 @@ func TestTemplate(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -7043,6 +7141,7 @@ func TestTextarea(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -7130,6 +7229,7 @@ func TestTFoot(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -7201,6 +7301,7 @@ func TestTh(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.Abbr("hello %v", 42)
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
@@ -7277,6 +7378,7 @@ func TestTHead(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -7348,6 +7450,7 @@ func TestTime(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -7420,6 +7523,7 @@ func TestTitle(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -7457,6 +7561,7 @@ func TestTr(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -7528,6 +7633,7 @@ func TestU(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -7599,6 +7705,7 @@ func TestUl(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -7670,6 +7777,7 @@ func TestVar(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -7741,6 +7849,7 @@ func TestVideo(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
@@ -7849,6 +7958,7 @@ func TestWbr(t *testing.T) {
 	elem.setEvents(nil)
 	elem.setParent(nil)
 	elem.setBody(nil)
+	elem.Key("foo")
 	elem.AccessKey("hello %v", 42)
 	elem.Aria("foo", "bar")
 	elem.Attr("foo", "bar")
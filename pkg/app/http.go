@@ -17,6 +17,7 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/maxence-charriere/go-app/v10/pkg/cache"
 	"github.com/maxence-charriere/go-app/v10/pkg/errors"
 )
 
@@ -96,6 +97,10 @@ type Handler struct {
 	CacheableResources []string
 
 	// RawHeaders contains extra HTML headers for the page's <head> section.
+	// The hashes of their inline scripts and styles are added to the script
+	// and style sources of the ContentSecurityPolicy. Their other elements,
+	// such as links, are not given a nonce and must be allowed by the policy
+	// sources, for example with 'self'.
 	RawHeaders []string
 
 	// HTML returns the page's HTML element. Defaults to app.Html().
@@ -138,6 +143,49 @@ type Handler struct {
 	// content length. Defaults to "Content-Length".
 	WasmContentLengthHeader string
 
+	// Hydrate makes the client adopt the HTML pre-rendered by the server for
+	// the first displayed page instead of replacing it, attaching event
+	// handlers to the existing elements. Mismatches between the pre-rendered
+	// HTML and the client rendering are logged and patched.
+	Hydrate bool
+
+	// Stream makes pre-rendered pages be sent progressively. The document
+	// head and the components that are ready are sent immediately, while the
+	// components that wait for goroutines started with Context.Async are
+	// sent as these finish, and swapped into the page by an inline script.
+	//
+	// The page is only sent once the navigation guards and the route loader
+	// are done, so that they can still redirect or cancel the navigation.
+	// Page information such as the title, the status code or the headers
+	// can only be set before any other asynchronous work when streaming.
+	Stream bool
+
+	// PreRenderContext returns the context used to pre-render the page
+	// requested by the given request. It is intended to enrich the request
+	// context with values such as the authenticated user or the user locale,
+	// which components retrieve from the Context given to OnPreRender.
+	// Defaults to the request context.
+	PreRenderContext func(r *http.Request) context.Context
+
+	// ContentSecurityPolicy describes the Content-Security-Policy header sent
+	// with pre-rendered pages.
+	ContentSecurityPolicy ContentSecurityPolicy
+
+	// PageCache stores pre-rendered pages to serve them again without
+	// rendering them. Only the pages of the routes described in
+	// PageCachePolicies are cached, and only when they are rendered with a
+	// 200 status code and do not set cookies, which are specific to the user
+	// that requested the page. Cached pages are never streamed.
+	//
+	// Caches with their own item expiration, such as cache.LRU and
+	// cache.Expire, must keep items at least as long as the longest TTL in
+	// PageCachePolicies.
+	PageCache cache.Cache
+
+	// PageCachePolicies maps route paths or patterns, as given to Route or
+	// RouteWithRegexp, to how their pre-rendered pages are cached.
+	PageCachePolicies map[string]PageCachePolicy
+
 	// ServiceWorkerTemplate defines the app-worker.js template, defaulting
 	// to DefaultAppWorkerJS. Modifications are discouraged to avoid potential
 	// issues with go-app functionality.
@@ -149,6 +197,10 @@ type Handler struct {
 	proxyResources       map[string]ProxyResource
 	cachedProxyResources *memoryCache
 	cachedPWAResources   *memoryCache
+	pageCacheGenerations pageCacheGenerations
+	scriptHashes         map[string]string
+	rawScriptHashes      []string
+	rawStyleHashes       []string
 }
 
 func (h *Handler) init() {
@@ -257,6 +309,13 @@ func (h *Handler) initPageContent() {
 
 }
 
+// pwaScripts lists the scripts generated by the Handler.
+var pwaScripts = []string{
+	"/wasm_exec.js",
+	"/app.js",
+	"/app-worker.js",
+}
+
 func (h *Handler) initPWAResources() {
 	h.cachedPWAResources = newMemoryCache(5)
 
@@ -278,6 +337,16 @@ func (h *Handler) initPWAResources() {
 		Body:        h.makeAppWorkerJS(),
 	})
 
+	h.scriptHashes = make(map[string]string)
+	for _, path := range pwaScripts {
+		i, _ := h.cachedPWAResources.Get(path)
+		h.scriptHashes[path] = cspHash(i.Body)
+	}
+	for _, header := range h.RawHeaders {
+		h.rawScriptHashes = append(h.rawScriptHashes, inlineHashes(header, "script")...)
+		h.rawStyleHashes = append(h.rawStyleHashes, inlineHashes(header, "style")...)
+	}
+
 	h.cachedPWAResources.Set(cacheItem{
 		Path:        "/manifest.webmanifest",
 		ContentType: "application/manifest+json",
@@ -300,6 +369,7 @@ func (h *Handler) makeAppJS() []byte {
 	h.Env["GOAPP_VERSION"] = h.Version
 	h.Env["GOAPP_STATIC_RESOURCES_URL"] = h.Resources.Resolve("/web")
 	h.Env["GOAPP_ROOT_PREFIX"] = h.Resources.Resolve("/")
+	h.Env["GOAPP_HYDRATE"] = strconv.FormatBool(h.Hydrate)
 
 	for k, v := range h.Env {
 		if err := os.Setenv(k, v); err != nil {
@@ -463,6 +533,14 @@ func (h *Handler) initProxyResources() {
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.once.Do(h.init)
 
+	// The unprefixed path is also matched for reverse proxies that strip the
+	// root prefix before forwarding requests.
+	if path := r.URL.Path; path == serverActionsPath ||
+		path == serverActionsEndpoint(h.Resources.Resolve("/")) {
+		h.serveServerAction(w, r)
+		return
+	}
+
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("ETag", h.etag)
 
@@ -591,13 +669,157 @@ func (h *Handler) serveProxyResource(resource ProxyResource, w http.ResponseWrit
 	h.serveCachedItem(w, item)
 }
 
-func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
-	if routed := routes.routed(r.URL.Path); !routed {
-		http.NotFound(w, r)
+// InvalidatePage invalidates the cached versions of the page at the given
+// path. The page is rendered again on its next request.
+func (h *Handler) InvalidatePage(path string) {
+	h.pageCacheGenerations.invalidatePath(path)
+}
+
+// InvalidateRoute invalidates the cached pages of the route registered with
+// the given path or pattern. The pages are rendered again on their next
+// request.
+func (h *Handler) InvalidateRoute(pattern string) {
+	h.pageCacheGenerations.invalidateRoute(pattern)
+}
+
+// InvalidatePages invalidates all the cached pages. The pages are rendered
+// again on their next request.
+func (h *Handler) InvalidatePages() {
+	h.pageCacheGenerations.invalidateAll()
+}
+
+// pageCacheKey returns the key under which the page requested by the given
+// request is cached, along with the duration while it stays cached. It returns
+// false when the page is not cacheable.
+func (h *Handler) pageCacheKey(r *http.Request) (string, time.Duration, bool) {
+	if h.PageCache == nil {
+		return "", 0, false
+	}
+
+	pattern, routed := routes.routePattern(r.URL.Path)
+	if !routed {
+		return "", 0, false
+	}
+
+	policy, ok := h.PageCachePolicies[pattern]
+	if !ok || policy.TTL <= 0 {
+		return "", 0, false
+	}
+
+	generation := h.pageCacheGenerations.key(pattern, r.URL.Path)
+	return generation + " " + policy.key(r), policy.TTL, true
+}
+
+func (h *Handler) serveCachedPage(w http.ResponseWriter, p cachedPage) {
+	for k, v := range p.header {
+		w.Header()[k] = v
+	}
+	if p.nonce != "" {
+		nonce := h.setContentSecurityPolicy(w)
+		p.body = bytes.ReplaceAll(p.body, []byte(p.nonce), []byte(nonce))
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(p.body)))
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(p.status)
+	w.Write(p.body)
+}
+
+// setContentSecurityPolicy sets the content security policy header and returns
+// the nonce it allows. It returns an empty string when there is no policy.
+func (h *Handler) setContentSecurityPolicy(w http.ResponseWriter) string {
+	if !h.ContentSecurityPolicy.enabled() {
+		return ""
+	}
+
+	scriptHashes := make([]string, 0, len(pwaScripts)+len(h.rawScriptHashes))
+	for _, path := range pwaScripts {
+		scriptHashes = append(scriptHashes, h.scriptHashes[path])
+	}
+	scriptHashes = append(scriptHashes, h.rawScriptHashes...)
+
+	nonce := newCSPNonce()
+	w.Header().Set(
+		h.ContentSecurityPolicy.headerName(),
+		h.ContentSecurityPolicy.header(nonce, scriptHashes, h.rawStyleHashes),
+	)
+	return nonce
+}
+
+func (h *Handler) serveServerAction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var action serverAction
+	body := http.MaxBytesReader(w, r.Body, maxServerActionBodySize)
+	if err := json.NewDecoder(body).Decode(&action); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	handler, ok := serverActionHandlers[action.Name]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	ctx := r.Context()
+	if h.PreRenderContext != nil {
+		ctx = h.PreRenderContext(r)
+	}
+
+	origin := *r.URL
+	origin.Scheme = "http"
+	page := makeRequestPage(&origin, h.Resources.Resolve)
+
+	engine := newEngine(ctx,
+		&routes,
+		h.Resources.Resolve,
+		&page,
+		nil,
+	)
+	engine.request = r
+
+	res, err := engine.handleServerAction(handler, action.action())
+	if err != nil {
+		Log(errors.New("handling server action failed").
+			WithTag("action", action.Name).
+			Wrap(err))
+		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	ctx := context.Background()
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		Log(errors.New("encoding server action response failed").
+			WithTag("action", action.Name).
+			Wrap(err))
+	}
+}
+
+func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
+	cacheKey, cacheTTL, cacheable := h.pageCacheKey(r)
+	if cacheable {
+		if i, ok := h.PageCache.Get(r.Context(), cacheKey); ok {
+			if p, ok := i.(cachedPage); ok && time.Now().Before(p.expiresAt) {
+				h.serveCachedPage(w, p)
+				return
+			}
+		}
+	}
+
+	ctx := r.Context()
+	if h.PreRenderContext != nil {
+		ctx = h.PreRenderContext(r)
+	}
 
 	origin := *r.URL
 	origin.Scheme = "http"
@@ -617,16 +839,80 @@ func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
 		&page,
 		actionHandlers,
 	)
+	engine.request = r
+	_, canFlush := w.(http.Flusher)
+	engine.streaming = h.Stream && canFlush && !cacheable
+
 	engine.Navigate(page.URL(), false)
-	engine.ConsumeAll()
+	if engine.streaming {
+		if err := engine.consumeNavigation(); err != nil {
+			return
+		}
+	} else {
+		engine.ConsumeAll()
+	}
+
+	for k, v := range page.header {
+		w.Header()[k] = v
+	}
+	if page.redirectURL != "" {
+		http.Redirect(w, r, page.redirectURL, page.Status())
+		return
+	}
+
+	nonce := h.setContentSecurityPolicy(w)
+	engine.scriptNonce = nonce
+
+	if engine.streaming {
+		w.Header().Set("Content-Type", "text/html")
+		if err := engine.EncodeStream(w, page.Status(), h.pageDocument(&page, nonce)); err != nil && ctx.Err() == nil {
+			Log(errors.New("encoding html document failed").Wrap(err))
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
 
+	var b bytes.Buffer
+	if err := engine.Encode(&b, h.pageDocument(&page, nonce)); err != nil {
+		Log(errors.New("encoding html document failed").Wrap(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if cacheable && page.Status() == http.StatusOK && page.header.Get("Set-Cookie") == "" {
+		h.PageCache.Set(r.Context(), cacheKey, cachedPage{
+			status:    page.Status(),
+			header:    page.header.Clone(),
+			body:      b.Bytes(),
+			nonce:     nonce,
+			expiresAt: time.Now().Add(cacheTTL),
+		})
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(b.Len()))
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(page.Status())
+	w.Write(b.Bytes())
+}
+
+func (h *Handler) pageDocument(page *requestPage, nonce string) HTMLHtml {
 	icon := h.Icon.SVG
 	if icon == "" {
 		icon = h.Icon.Default
 	}
 
-	var b bytes.Buffer
-	err := engine.Encode(&b, h.HTML().
+	wasmExecScript := Script().
+		Defer(true).
+		Src("/wasm_exec.js")
+	appScript := Script().
+		Defer(true).
+		Src("/app.js")
+	if nonce != "" {
+		wasmExecScript = wasmExecScript.Attr("integrity", h.scriptHashes["/wasm_exec.js"])
+		appScript = appScript.Attr("integrity", h.scriptHashes["/app.js"])
+	}
+
+	document := h.HTML().
 		Lang(page.Lang()).
 		privateBody(
 			Head().Body(
@@ -726,12 +1012,8 @@ func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
 					}
 					return nil
 				}),
-				Script().
-					Defer(true).
-					Src("/wasm_exec.js"),
-				Script().
-					Defer(true).
-					Src("/app.js"),
+				wasmExecScript,
+				appScript,
 				Range(h.Scripts).Slice(func(i int) UI {
 					if resource := parseHTTPResource(h.Scripts[i]); resource.URL != "" {
 						return resource.toScript()
@@ -759,16 +1041,12 @@ func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
 							Text(page.loadingLabel),
 					),
 			),
-		))
-	if err != nil {
-		Log(errors.New("encoding html document failed").Wrap(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
+		)
 
-	w.Header().Set("Content-Length", strconv.Itoa(b.Len()))
-	w.Header().Set("Content-Type", "text/html")
-	w.Write(b.Bytes())
+	if nonce != "" {
+		setNonce(document, nonce, h.ContentSecurityPolicy.styleNonce())
+	}
+	return document
 }
 
 func (h *Handler) serveLibrary(w http.ResponseWriter, r *http.Request, library []byte) {
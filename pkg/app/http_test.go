@@ -4,17 +4,27 @@
 package app
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/maxence-charriere/go-app/v10/pkg/cache"
 	"github.com/stretchr/testify/require"
 )
 
 func init() {
 	Route("/", func() Composer { return &preRenderTestCompo{} })
+	Route("/status-test", func() Composer { return &preRenderStatusTestCompo{} })
+	Route("/redirect-test", func() Composer { return &preRenderRedirectTestCompo{} })
+	Route("/stream-test", func() Composer { return &streamCompo{} })
+	Route("/stream-redirect-test", func() Composer { return &streamRedirectCompo{} })
+	Route("/cache-test/{id}", func() Composer { return &preRenderCacheTestCompo{} })
+	Route("/request-test", func() Composer { return &preRenderRequestTestCompo{} })
 }
 
 type preRenderTestCompo struct {
@@ -29,6 +39,85 @@ func (c *preRenderTestCompo) Render() UI {
 		)
 }
 
+type preRenderStatusTestCompo struct {
+	Compo
+}
+
+func (c *preRenderStatusTestCompo) OnPreRender(ctx Context) {
+	ctx.Page().SetStatus(http.StatusGone)
+	ctx.Page().SetHeader("X-Status-Test", "gone")
+}
+
+func (c *preRenderStatusTestCompo) Render() UI {
+	return Div().ID("pre-render-status")
+}
+
+type preRenderRedirectTestCompo struct {
+	Compo
+}
+
+func (c *preRenderRedirectTestCompo) OnPreRender(ctx Context) {
+	ctx.Page().Redirect("/status-test", http.StatusMovedPermanently)
+}
+
+func (c *preRenderRedirectTestCompo) Render() UI {
+	return Div()
+}
+
+type streamRedirectCompo struct {
+	Compo
+}
+
+func (c *streamRedirectCompo) OnLoad(ctx Context, n *Navigation) error {
+	time.Sleep(time.Millisecond * 10)
+	n.Redirect("/status-test")
+	return nil
+}
+
+func (c *streamRedirectCompo) Render() UI {
+	return Div()
+}
+
+var preRenderCacheTestCount int
+
+type preRenderCacheTestCompo struct {
+	Compo
+}
+
+func (c *preRenderCacheTestCompo) OnPreRender(ctx Context) {
+	preRenderCacheTestCount++
+	ctx.Page().SetHeader("X-Cache-Test", ctx.Param("id"))
+	if ctx.Param("id") == "cookie" {
+		ctx.Page().SetHeader("Set-Cookie", "session=alice")
+	}
+}
+
+func (c *preRenderCacheTestCompo) Render() UI {
+	return Div().ID("pre-render-cache")
+}
+
+type preRenderRequestTestKey struct{}
+
+type preRenderRequestTestCompo struct {
+	Compo
+
+	lang  string
+	theme string
+	user  string
+}
+
+func (c *preRenderRequestTestCompo) OnPreRender(ctx Context) {
+	c.lang = ctx.RequestHeader("Accept-Language")
+	c.theme = ctx.RequestCookie("theme")
+	c.user, _ = ctx.Value(preRenderRequestTestKey{}).(string)
+}
+
+func (c *preRenderRequestTestCompo) Render() UI {
+	return Div().
+		ID("pre-render-request").
+		Text(c.lang + " " + c.theme + " " + c.user)
+}
+
 func TestHandlerServePageWithLocalDir(t *testing.T) {
 	r := httptest.NewRequest(http.MethodGet, "/", nil)
 	w := httptest.NewRecorder()
@@ -466,6 +555,242 @@ func TestHandlerServeAppWasm(t *testing.T) {
 	}
 }
 
+func TestHandlerServePageStatus(t *testing.T) {
+	h := Handler{}
+
+	t.Run("page status and headers are set", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/status-test", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusGone, w.Code)
+		require.Equal(t, "gone", w.Header().Get("X-Status-Test"))
+		require.Contains(t, w.Body.String(), `<div id="pre-render-status">`)
+	})
+
+	t.Run("page is redirected", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/redirect-test", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusMovedPermanently, w.Code)
+		require.Equal(t, "/status-test", w.Header().Get("Location"))
+	})
+
+	t.Run("not found page is rendered with a not found status", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/not-routed", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Contains(t, w.Body.String(), "goapp-notfound-title")
+	})
+}
+
+func TestHandlerServePageStream(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/stream-test", nil)
+	w := httptest.NewRecorder()
+
+	h := Handler{Stream: true}
+	h.ServeHTTP(w, r)
+
+	body := w.Body.String()
+	require.Equal(t, http.StatusOK, w.Code)
+	require.True(t, w.Flushed)
+	require.Empty(t, w.Header().Get("Content-Length"))
+	require.Contains(t, body, `<template id="goapp-stream-1"></template>`)
+	require.Contains(t, body, `<template id="goapp-stream-chunk-1">`)
+	require.Contains(t, body, "loaded")
+	require.Contains(t, body, `id="app-wasm-loader"`)
+}
+
+func TestHandlerServePageStreamRedirect(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/stream-redirect-test", nil)
+	w := httptest.NewRecorder()
+
+	h := Handler{Stream: true}
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusFound, w.Code)
+	require.Equal(t, "/status-test", w.Header().Get("Location"))
+	require.NotContains(t, w.Body.String(), "goapp-stream")
+}
+
+func TestHandlerServePageCache(t *testing.T) {
+	h := Handler{
+		PageCache: &cache.LRU{ItemTTL: time.Hour},
+		PageCachePolicies: map[string]PageCachePolicy{
+			"/cache-test/{id}": {TTL: time.Minute},
+		},
+	}
+
+	serve := func(t *testing.T, path string) {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `<div id="pre-render-cache">`)
+	}
+
+	t.Run("page is cached", func(t *testing.T) {
+		preRenderCacheTestCount = 0
+		serve(t, "/cache-test/1")
+		serve(t, "/cache-test/1")
+		require.Equal(t, 1, preRenderCacheTestCount)
+		require.Equal(t, 1, h.PageCache.Len())
+	})
+
+	t.Run("cached page headers are served", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/cache-test/1", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.Equal(t, "1", w.Header().Get("X-Cache-Test"))
+	})
+
+	t.Run("invalidated page is rendered", func(t *testing.T) {
+		preRenderCacheTestCount = 0
+		serve(t, "/cache-test/2")
+		h.InvalidatePage("/cache-test/2")
+		serve(t, "/cache-test/2")
+		serve(t, "/cache-test/1")
+		require.Equal(t, 2, preRenderCacheTestCount)
+	})
+
+	t.Run("invalidated route pages are rendered", func(t *testing.T) {
+		preRenderCacheTestCount = 0
+		h.InvalidateRoute("/cache-test/{id}")
+		serve(t, "/cache-test/1")
+		serve(t, "/cache-test/2")
+		serve(t, "/cache-test/2")
+		require.Equal(t, 2, preRenderCacheTestCount)
+	})
+
+	t.Run("invalidated pages are rendered", func(t *testing.T) {
+		preRenderCacheTestCount = 0
+		h.InvalidatePages()
+		serve(t, "/cache-test/1")
+		serve(t, "/cache-test/1")
+		require.Equal(t, 1, preRenderCacheTestCount)
+	})
+
+	t.Run("expired page is rendered", func(t *testing.T) {
+		h.PageCachePolicies["/cache-test/{id}"] = PageCachePolicy{TTL: time.Nanosecond}
+		defer func() {
+			h.PageCachePolicies["/cache-test/{id}"] = PageCachePolicy{TTL: time.Minute}
+		}()
+
+		preRenderCacheTestCount = 0
+		serve(t, "/cache-test/3")
+		time.Sleep(time.Millisecond)
+		serve(t, "/cache-test/3")
+		require.Equal(t, 2, preRenderCacheTestCount)
+	})
+
+	t.Run("page setting a cookie is not cached", func(t *testing.T) {
+		preRenderCacheTestCount = 0
+		serve(t, "/cache-test/cookie")
+		serve(t, "/cache-test/cookie")
+		require.Equal(t, 2, preRenderCacheTestCount)
+	})
+
+	t.Run("page without policy is not cached", func(t *testing.T) {
+		len := h.PageCache.Len()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.Equal(t, len, h.PageCache.Len())
+	})
+}
+
+func TestHandlerServePageRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/request-test", nil)
+	r.Header.Set("Accept-Language", "fr")
+	r.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+	w := httptest.NewRecorder()
+
+	h := Handler{
+		PreRenderContext: func(r *http.Request) context.Context {
+			return context.WithValue(r.Context(), preRenderRequestTestKey{}, "maxence")
+		},
+	}
+	h.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `<div id="pre-render-request">fr dark maxence</div>`)
+}
+
+func TestHandlerServePageContentSecurityPolicy(t *testing.T) {
+	h := Handler{
+		Scripts: []string{"/web/hello.js"},
+		RawHeaders: []string{
+			`<script>console.log("raw")</script>`,
+			`<style>body { margin: 0; }</style>`,
+		},
+		ContentSecurityPolicy: ContentSecurityPolicy{
+			Directives: map[string][]string{
+				"default-src": {"'self'"},
+			},
+		},
+		PageCache: &cache.LRU{ItemTTL: time.Hour},
+		PageCachePolicies: map[string]PageCachePolicy{
+			"/cache-test/{id}": {TTL: time.Minute},
+		},
+	}
+
+	serve := func(t *testing.T, path string) string {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		policy := w.Header().Get("Content-Security-Policy")
+		require.Contains(t, policy, "'wasm-unsafe-eval'")
+		require.Contains(t, policy, "'"+h.scriptHashes["/app.js"]+"'")
+		require.Contains(t, policy, "'"+cspHash([]byte(`console.log("raw")`))+"'")
+		require.Contains(t, policy, "'"+cspHash([]byte(`body { margin: 0; }`))+"'")
+
+		nonce := strings.TrimPrefix(policy, "default-src 'self'; script-src 'self' 'nonce-")
+		nonce = nonce[:strings.Index(nonce, "'")]
+		body := w.Body.String()
+		require.Equal(t, 7, strings.Count(body, `nonce="`+nonce+`"`))
+		require.Contains(t, body, `integrity="`+h.scriptHashes["/app.js"]+`"`)
+		return nonce
+	}
+
+	t.Run("page is served with a policy", func(t *testing.T) {
+		require.NotEqual(t, serve(t, "/"), serve(t, "/"))
+	})
+
+	t.Run("cached page is served with a new nonce", func(t *testing.T) {
+		require.NotEqual(t, serve(t, "/cache-test/csp"), serve(t, "/cache-test/csp"))
+	})
+
+	t.Run("styles allowing unsafe inline are served without nonce", func(t *testing.T) {
+		h := Handler{
+			RawHeaders: []string{`<style>body { margin: 0; }</style>`},
+			ContentSecurityPolicy: ContentSecurityPolicy{
+				Directives: map[string][]string{
+					"default-src": {"'self'"},
+					"style-src":   {"'self'", "'unsafe-inline'"},
+				},
+			},
+		}
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		policy := w.Header().Get("Content-Security-Policy")
+		require.Contains(t, policy, "style-src 'self' 'unsafe-inline'")
+		require.NotContains(t, policy, cspHash([]byte(`body { margin: 0; }`)))
+
+		body := w.Body.String()
+		require.Contains(t, body, `<link href="/app.css"`)
+		require.NotRegexp(t, `<(link|style)[^>]* nonce=`, body)
+		require.Regexp(t, `<script[^>]* nonce=`, body)
+	})
+}
+
 func TestHandlerServeFile(t *testing.T) {
 	close := testCreateDir(t, "web")
 	defer close()
@@ -118,6 +118,9 @@ func (v value) appendChild(c Wrapper) {
 func (v value) replaceChild(new, old Wrapper) {
 }
 
+func (v value) insertBefore(new, ref Wrapper) {
+}
+
 func (v value) removeChild(c Wrapper) {
 }
 
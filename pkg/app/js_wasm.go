@@ -106,6 +106,14 @@ func (v value) replaceChild(new, old Wrapper) {
 	v.Call("replaceChild", new, old)
 }
 
+func (v value) insertBefore(new, ref Wrapper) {
+	if ref == nil {
+		v.appendChild(new)
+		return
+	}
+	v.Call("insertBefore", new, ref)
+}
+
 func (v value) removeChild(c Wrapper) {
 	v.Call("removeChild", c)
 }
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"net/url"
+
+	"github.com/maxence-charriere/go-app/v10/pkg/errors"
+)
+
+// Navigation describes a navigation from a page to another. It is given to
+// the components that implement BeforeLeaver, BeforeEnterer, or Loader, which
+// can cancel or redirect it before the destination page is displayed.
+type Navigation struct {
+	// From is the URL of the page being left. It is nil when navigating to the
+	// first page displayed by the app.
+	From *url.URL
+
+	// To is the destination URL.
+	To *url.URL
+
+	cancelled bool
+	redirect  *url.URL
+}
+
+// Cancel cancels the navigation. The current page stays displayed.
+func (n *Navigation) Cancel() {
+	n.cancelled = true
+}
+
+// Cancelled reports whether the navigation was cancelled or redirected.
+func (n *Navigation) Cancelled() bool {
+	return n.cancelled
+}
+
+// Redirect cancels the navigation and navigates to the given URL instead.
+func (n *Navigation) Redirect(rawURL string) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		Log(errors.New("redirecting navigation failed").
+			WithTag("url", rawURL).
+			Wrap(err))
+		n.Cancel()
+		return
+	}
+	n.RedirectTo(u)
+}
+
+// RedirectTo cancels the navigation and navigates to the given URL instead.
+func (n *Navigation) RedirectTo(u *url.URL) {
+	n.cancelled = true
+	n.redirect = u
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNavigation(t *testing.T) {
+	t.Run("cancelled navigation is reported", func(t *testing.T) {
+		var n Navigation
+		require.False(t, n.Cancelled())
+
+		n.Cancel()
+		require.True(t, n.Cancelled())
+	})
+
+	t.Run("redirected navigation is reported as cancelled", func(t *testing.T) {
+		var n Navigation
+		n.Redirect("/hello")
+		require.True(t, n.Cancelled())
+		require.Equal(t, "/hello", n.redirect.String())
+	})
+
+	t.Run("navigation redirected to an invalid url is cancelled", func(t *testing.T) {
+		var n Navigation
+		n.Redirect(":")
+		require.True(t, n.Cancelled())
+		require.Nil(t, n.redirect)
+	})
+
+	t.Run("navigation redirected to a parsed url is reported as cancelled", func(t *testing.T) {
+		u, _ := url.Parse("/hello")
+
+		var n Navigation
+		n.RedirectTo(u)
+		require.True(t, n.Cancelled())
+		require.Equal(t, u, n.redirect)
+	})
+}
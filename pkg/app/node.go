@@ -2,11 +2,15 @@ package app
 
 import (
 	"bytes"
+	"fmt"
 	"html"
 	"io"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf16"
 
 	"github.com/maxence-charriere/go-app/v10/pkg/errors"
 )
@@ -155,6 +159,7 @@ func (m nodeManager) mountHTML(ctx Context, depth uint, v HTML) (UI, error) {
 	for i, child := range children {
 		var err error
 		if child, err = m.Mount(ctx, depth+1, child); err != nil {
+			m.dismountPartialHTML(v, i)
 			return nil, errors.New("mounting child failed").
 				WithTag("type", reflect.TypeOf(v)).
 				WithTag("tag", v.Tag()).
@@ -170,6 +175,19 @@ func (m nodeManager) mountHTML(ctx Context, depth uint, v HTML) (UI, error) {
 	return v, nil
 }
 
+// dismountPartialHTML dismounts an HTML element whose mounting failed at the
+// child with the given index. The children that come after it were never
+// mounted and are left untouched.
+func (m nodeManager) dismountPartialHTML(v HTML, failedChild int) {
+	for _, child := range v.body()[:failedChild] {
+		m.Dismount(child)
+	}
+	for _, handler := range v.events() {
+		m.dismountHTMLEventHandler(handler)
+	}
+	v.setJSElement(nil)
+}
+
 func (m nodeManager) mountHTMLAttributes(ctx Context, v HTML) {
 	for name, value := range v.attrs() {
 		setJSAttribute(v.JSValue(), name, resolveAttributeURLValue(
@@ -222,22 +240,31 @@ func (m nodeManager) mountComponent(ctx Context, depth uint, v Composer) (UI, er
 			WithTag("depth", v.depth())
 	}
 
-	v = v.setRef(v)
-	v = v.setDepth(depth)
-
-	if initializer, ok := v.(Initializer); ok {
-		initializer.OnInit()
+	ctx = m.componentContext(ctx, v)
+	root, err := m.mountComponentRoot(ctx, depth, v)
+	if err != nil {
+		if root, err = m.mountErrorFallback(ctx, depth, v, err); err != nil {
+			// Not dismounted: its OnMount dispatch is dropped once the ref
+			// is cleared, so OnDismount would not be balanced.
+			v.setRef(nil)
+			return nil, err
+		}
 	}
+	root = root.setParent(v)
+	v = v.setRoot(root)
 
-	if preRenderer, ok := v.(PreRenderer); ok && IsServer {
-		ctx.Dispatch(preRenderer.OnPreRender)
-	}
+	return v, nil
+}
 
-	if mounter, ok := v.(Mounter); ok && IsClient {
-		ctx.Dispatch(mounter.OnMount)
+func (m nodeManager) mountComponentRoot(ctx Context, depth uint, v Composer) (UI, error) {
+	if err := m.call(ctx, func() { v = m.initComponent(ctx, depth, v) }); err != nil {
+		return nil, errors.New("initializing component failed").
+			WithTag("type", reflect.TypeOf(v)).
+			WithTag("depth", depth).
+			Wrap(err)
 	}
 
-	root, err := m.renderComponent(v)
+	root, err := m.renderComponent(ctx, v)
 	if err != nil {
 		return nil, errors.New("rendering component failed").
 			WithTag("type", reflect.TypeOf(v)).
@@ -250,16 +277,55 @@ func (m nodeManager) mountComponent(ctx Context, depth uint, v Composer) (UI, er
 			WithTag("depth", v.depth()).
 			Wrap(err)
 	}
-	root = root.setParent(v)
-	v = v.setRoot(root)
+	return root, nil
+}
 
-	return v, nil
+// mountErrorFallback mounts the fallback of the given component when it is an
+// error boundary that catches the given error. Otherwise, the error is
+// returned.
+func (m nodeManager) mountErrorFallback(ctx Context, depth uint, v Composer, err error) (UI, error) {
+	boundary, ok := v.(ErrorBoundary)
+	if !ok || v.fallback() != nil {
+		return nil, err
+	}
+
+	fallback, err := m.Mount(ctx, depth+1, m.catchError(ctx, boundary, err))
+	if err != nil {
+		return nil, errors.New("mounting error fallback failed").
+			WithTag("type", reflect.TypeOf(v)).
+			WithTag("depth", depth).
+			Wrap(err)
+	}
+	return fallback, nil
 }
 
-func (m nodeManager) renderComponent(v Composer) (UI, error) {
-	rendering := FilterUIElems(v.Render())
+func (m nodeManager) initComponent(ctx Context, depth uint, v Composer) Composer {
+	v = v.setRef(v)
+	v = v.setDepth(depth)
+
+	if initializer, ok := v.(Initializer); ok {
+		initializer.OnInit()
+	}
+
+	if preRenderer, ok := v.(PreRenderer); ok && IsServer {
+		m.dispatchLifecycle(ctx, v, preRenderer.OnPreRender)
+	}
+
+	if mounter, ok := v.(Mounter); ok && IsClient {
+		m.dispatchLifecycle(ctx, v, mounter.OnMount)
+	}
+
+	return v
+}
+
+func (m nodeManager) renderComponent(ctx Context, v Composer) (UI, error) {
+	var rendering []UI
+	if err := m.call(ctx, func() { rendering = FilterUIElems(v.Render()) }); err != nil {
+		return nil, err
+	}
 	if len(rendering) == 0 {
-		return nil, errors.New("render method does not returns a text, html element, or component")
+		return nil, errors.New("render method does not returns a text, html element, or component").
+			WithTag("component-path", ctx.componentScope.path())
 	}
 	return rendering[0], nil
 }
@@ -280,6 +346,273 @@ func (m nodeManager) mountRawHTML(depth uint, v *raw) (UI, error) {
 	return v, nil
 }
 
+// Hydrate mounts a UI element by adopting the given DOM node, typically
+// produced by a server-side Encode, instead of creating a new one. Event
+// handlers are attached to the adopted nodes, and mismatches between the UI
+// element and the DOM are logged and patched. A node that cannot be adopted is
+// replaced within the parent by a newly mounted element.
+func (m nodeManager) Hydrate(ctx Context, depth uint, v UI, parent, node Value) (UI, error) {
+	ctx = m.context(ctx, v)
+
+	switch v := v.(type) {
+	case *text:
+		return m.hydrateText(ctx, depth, v, parent, node)
+
+	case HTML:
+		return m.hydrateHTML(ctx, depth, v, parent, node)
+
+	case Composer:
+		return m.hydrateComponent(ctx, depth, v, parent, node)
+
+	case *raw:
+		return m.hydrateRawHTML(ctx, depth, v, parent, node)
+
+	default:
+		return nil, errors.New("unsupported element").
+			WithTag("type", reflect.TypeOf(v)).
+			WithTag("depth", depth)
+	}
+}
+
+func (m nodeManager) hydrateText(ctx Context, depth uint, v *text, parent, node Value) (UI, error) {
+	if v.Mounted() {
+		return nil, errors.New("text is already mounted").
+			WithTag("parent-type", reflect.TypeOf(v.parent())).
+			WithTag("preview-value", previewText(v.value))
+	}
+
+	value := strings.TrimLeftFunc(v.value, unicode.IsSpace)
+	if value == "" {
+		v.jsvalue = Window().createTextNode(v.value)
+		parent.insertBefore(v, node)
+		return v, nil
+	}
+
+	if nodeType(node) != textNodeType {
+		return m.hydrateMismatch(ctx, depth, v, parent, node, "text node expected")
+	}
+
+	nodeValue := node.Get("nodeValue").String()
+	trimmedNodeValue := strings.TrimLeftFunc(nodeValue, unicode.IsSpace)
+	if !strings.HasPrefix(trimmedNodeValue, value) {
+		return m.hydrateMismatch(ctx, depth, v, parent, node, "text value differs")
+	}
+
+	if end := len(nodeValue) - len(trimmedNodeValue) + len(value); end < len(nodeValue) {
+		node.Call("splitText", len(utf16.Encode([]rune(nodeValue[:end]))))
+	}
+	v.jsvalue = node
+	if nodeValue != v.value {
+		node.setNodeValue(v.value)
+	}
+	return v, nil
+}
+
+func (m nodeManager) hydrateHTML(ctx Context, depth uint, v HTML, parent, node Value) (UI, error) {
+	if v.Mounted() {
+		return nil, errors.New("html element is already mounted").
+			WithTag("parent-type", reflect.TypeOf(v.parent())).
+			WithTag("type", reflect.TypeOf(v)).
+			WithTag("tag", v.Tag()).
+			WithTag("depth", v.depth())
+	}
+
+	if nodeType(node) != elementNodeType ||
+		!strings.EqualFold(node.Get("tagName").String(), v.Tag()) {
+		return m.hydrateMismatch(ctx, depth, v, parent, node, "<"+v.Tag()+"> element expected")
+	}
+
+	v = v.setJSElement(node)
+	m.hydrateHTMLAttributes(ctx, v)
+	m.mountHTMLEventHandlers(ctx, v)
+
+	v = v.setDepth(depth).(HTML)
+	if v.SelfClosing() {
+		return v, nil
+	}
+
+	children := v.body()
+	child := node.Get("firstChild")
+	for i, c := range children {
+		for ignorableNode(child) {
+			next := child.Get("nextSibling")
+			node.Call("removeChild", child)
+			child = next
+		}
+
+		c, err := m.Hydrate(ctx, depth+1, c, node, child)
+		if err != nil {
+			m.dismountPartialHTML(v, i)
+			return nil, errors.New("hydrating child failed").
+				WithTag("type", reflect.TypeOf(v)).
+				WithTag("tag", v.Tag()).
+				WithTag("depth", depth).
+				WithTag("index", i).
+				Wrap(err)
+		}
+		c = c.setParent(v)
+		children[i] = c
+		child = c.JSValue().Get("nextSibling")
+	}
+
+	for nodeType(child) != 0 {
+		if !ignorableNode(child) {
+			Log(errors.New("hydration mismatch").
+				WithTag("reason", "unexpected node removed").
+				WithTag("type", reflect.TypeOf(v)).
+				WithTag("tag", v.Tag()).
+				WithTag("depth", depth))
+		}
+
+		next := child.Get("nextSibling")
+		node.Call("removeChild", child)
+		child = next
+	}
+
+	return v, nil
+}
+
+func (m nodeManager) hydrateHTMLAttributes(ctx Context, v HTML) {
+	jsElement := v.JSValue()
+	attrs := v.attrs()
+
+	domAttrs := jsElement.Get("attributes")
+	for i := domAttrs.Length() - 1; i >= 0; i-- {
+		name := domAttrs.Index(i).Get("name").String()
+		if _, ok := attrs[name]; !ok {
+			Log(errors.New("hydration mismatch").
+				WithTag("reason", "unexpected attribute removed").
+				WithTag("tag", v.Tag()).
+				WithTag("attribute", name))
+			deleteJSAttribute(jsElement, name)
+		}
+	}
+
+	for name, value := range attrs {
+		value = resolveAttributeURLValue(name, value, ctx.ResolveStaticResource)
+
+		switch name {
+		case "value", "contenteditable", "ismap", "readonly", "async", "autofocus",
+			"autoplay", "checked", "default", "defer", "disabled", "hidden", "loop",
+			"multiple", "muted", "open", "required", "reversed", "selected":
+			setJSAttribute(jsElement, name, value)
+			continue
+
+		case "id", "class", "title":
+			if value == "" {
+				continue
+			}
+		}
+
+		encodedValue := value
+		if encodedValue == "true" {
+			encodedValue = ""
+		}
+		if jsElement.Call("hasAttribute", name).Bool() && jsElement.getAttr(name) == encodedValue {
+			continue
+		}
+
+		Log(errors.New("hydration mismatch").
+			WithTag("reason", "attribute value differs").
+			WithTag("tag", v.Tag()).
+			WithTag("attribute", name).
+			WithTag("value", value))
+		setJSAttribute(jsElement, name, value)
+	}
+}
+
+func (m nodeManager) hydrateComponent(ctx Context, depth uint, v Composer, parent, node Value) (UI, error) {
+	if v.Mounted() {
+		return nil, errors.New("component is already mounted").
+			WithTag("parent-type", reflect.TypeOf(v.parent())).
+			WithTag("type", reflect.TypeOf(v)).
+			WithTag("depth", v.depth())
+	}
+
+	ctx = m.componentContext(ctx, v)
+	root, err := m.hydrateComponentRoot(ctx, depth, v, parent, node)
+	if err != nil {
+		if root, err = m.mountErrorFallback(ctx, depth, v, err); err != nil {
+			v.setRef(nil)
+			return nil, err
+		}
+
+		if nodeType(node) == 0 {
+			parent.appendChild(root)
+		} else {
+			parent.replaceChild(root, node)
+		}
+	}
+	root = root.setParent(v)
+	v = v.setRoot(root)
+
+	return v, nil
+}
+
+func (m nodeManager) hydrateComponentRoot(ctx Context, depth uint, v Composer, parent, node Value) (UI, error) {
+	if err := m.call(ctx, func() { v = m.initComponent(ctx, depth, v) }); err != nil {
+		return nil, errors.New("initializing component failed").
+			WithTag("type", reflect.TypeOf(v)).
+			WithTag("depth", depth).
+			Wrap(err)
+	}
+
+	root, err := m.renderComponent(ctx, v)
+	if err != nil {
+		return nil, errors.New("rendering component failed").
+			WithTag("type", reflect.TypeOf(v)).
+			WithTag("depth", v.depth()).
+			Wrap(err)
+	}
+	if root, err = m.Hydrate(ctx, depth+1, root, parent, node); err != nil {
+		return nil, errors.New("hydrating component root failed").
+			WithTag("type", reflect.TypeOf(v)).
+			WithTag("depth", v.depth()).
+			Wrap(err)
+	}
+	return root, nil
+}
+
+func (m nodeManager) hydrateRawHTML(ctx Context, depth uint, v *raw, parent, node Value) (UI, error) {
+	if v.Mounted() {
+		return nil, errors.New("raw html is already mounted").
+			WithTag("parent-type", reflect.TypeOf(v.parent())).
+			WithTag("type", reflect.TypeOf(v)).
+			WithTag("depth", v.depth()).
+			WithTag("raw-preview", previewText(v.value))
+	}
+
+	switch nodeType(node) {
+	case elementNodeType, textNodeType:
+		v.jsElement = node
+		return v, nil
+
+	default:
+		return m.hydrateMismatch(ctx, depth, v, parent, node, "raw html node expected")
+	}
+}
+
+func (m nodeManager) hydrateMismatch(ctx Context, depth uint, v UI, parent, node Value, reason string) (UI, error) {
+	Log(errors.New("hydration mismatch").
+		WithTag("reason", reason).
+		WithTag("type", reflect.TypeOf(v)).
+		WithTag("depth", depth))
+
+	v, err := m.Mount(ctx, depth, v)
+	if err != nil {
+		return nil, errors.New("mounting mismatched element failed").
+			WithTag("reason", reason).
+			Wrap(err)
+	}
+
+	if nodeType(node) == 0 {
+		parent.appendChild(v)
+	} else {
+		parent.replaceChild(v, node)
+	}
+	return v, nil
+}
+
 // Dismount removes a UI element based on its type.
 func (m nodeManager) Dismount(v UI) {
 	switch v := v.(type) {
@@ -317,6 +650,7 @@ func (m nodeManager) dismountHTMLEventHandler(handler eventHandler) {
 func (m nodeManager) dismountComponent(v Composer) {
 	m.Dismount(v.root())
 	v.setRef(nil)
+	v.setFallback(nil)
 
 	if dismounter, ok := v.(Dismounter); ok {
 		dismounter.OnDismount()
@@ -328,8 +662,8 @@ func (m nodeManager) dismountRawHTML(v *raw) {
 }
 
 // CanUpdate determines whether a given UI element 'v' can be updated with a new
-// UI element 'new'. It returns false if the types of the two elements are
-// different.
+// UI element 'new'. It returns false if the types or the keys of the two
+// elements are different.
 //
 // For HTML elements, it ensures that the tag names match. Otherwise, it returns
 // true indicating that an update is feasible.
@@ -337,6 +671,9 @@ func (m nodeManager) CanUpdate(v, new UI) bool {
 	if vType, newType := reflect.TypeOf(v), reflect.TypeOf(new); vType != newType {
 		return false
 	}
+	if keyOf(v) != keyOf(new) {
+		return false
+	}
 
 	switch v.(type) {
 	case *htmlElem, *htmlElemSelfClosing:
@@ -403,8 +740,31 @@ func (m nodeManager) updateHTML(ctx Context, v, new HTML) (UI, error) {
 		m.updateHTMLEventHandlers(ctx, v, newEvents)
 	}
 
+	children, err := m.updateHTMLChildren(ctx, v, new.body())
+	if err != nil {
+		return nil, err
+	}
+
+	v = v.setBody(children)
+	return v, nil
+}
+
+func (m nodeManager) updateHTMLChildren(ctx Context, v HTML, newChildren []UI) ([]UI, error) {
 	children := v.body()
-	newChildren := new.body()
+	for _, child := range children {
+		if keyOf(child) != nil {
+			return m.updateHTMLKeyedChildren(ctx, v, children, newChildren)
+		}
+	}
+	for _, child := range newChildren {
+		if keyOf(child) != nil {
+			return m.updateHTMLKeyedChildren(ctx, v, children, newChildren)
+		}
+	}
+	return m.updateHTMLIndexedChildren(ctx, v, children, newChildren)
+}
+
+func (m nodeManager) updateHTMLIndexedChildren(ctx Context, v HTML, children, newChildren []UI) ([]UI, error) {
 	sharedLen := min(len(children), len(newChildren))
 	for i := 0; i < min(len(children), len(newChildren)); i++ {
 		child := children[i]
@@ -461,8 +821,97 @@ func (m nodeManager) updateHTML(ctx Context, v, new HTML) (UI, error) {
 		children = append(children, newChild)
 	}
 
-	v = v.setBody(children)
-	return v, nil
+	return children, nil
+}
+
+// updateHTMLKeyedChildren reconciles children where at least one element is
+// keyed. Keyed children are matched by key and unkeyed ones by their order
+// among unkeyed siblings. Matched children are updated in place and only moved
+// when they are not part of the longest sequence that kept its relative order.
+func (m nodeManager) updateHTMLKeyedChildren(ctx Context, v HTML, children, newChildren []UI) ([]UI, error) {
+	keyed := make(map[any]int)
+	var unkeyed []int
+	for i, child := range children {
+		if key := keyOf(child); key != nil {
+			keyed[key] = i
+			continue
+		}
+		unkeyed = append(unkeyed, i)
+	}
+
+	matches := make([]int, len(newChildren))
+	matched := make([]bool, len(children))
+	for i, newChild := range newChildren {
+		matches[i] = -1
+
+		j := -1
+		if key := keyOf(newChild); key != nil {
+			if idx, ok := keyed[key]; ok {
+				j = idx
+			}
+		} else if len(unkeyed) != 0 {
+			j = unkeyed[0]
+			unkeyed = unkeyed[1:]
+		}
+
+		if j >= 0 && !matched[j] && m.CanUpdate(children[j], newChild) {
+			matches[i] = j
+			matched[j] = true
+		}
+	}
+
+	for i, child := range children {
+		if !matched[i] {
+			v.JSValue().removeChild(child)
+			m.Dismount(child)
+		}
+	}
+
+	updatedChildren := make([]UI, len(newChildren))
+	for i, newChild := range newChildren {
+		if j := matches[i]; j >= 0 {
+			child, err := m.Update(ctx, children[j], newChild)
+			if err != nil {
+				return nil, errors.New("updating child failed").
+					WithTag("type", reflect.TypeOf(v)).
+					WithTag("tag", v.Tag()).
+					WithTag("depth", v.depth()).
+					WithTag("index", i).
+					WithTag("key", keyOf(newChild)).
+					Wrap(err)
+			}
+			updatedChildren[i] = child
+			continue
+		}
+
+		child, err := m.Mount(ctx, v.depth()+1, newChild)
+		if err != nil {
+			return nil, errors.New("mounting child failed").
+				WithTag("type", reflect.TypeOf(v)).
+				WithTag("tag", v.Tag()).
+				WithTag("depth", v.depth()).
+				WithTag("index", i).
+				WithTag("key", keyOf(newChild)).
+				Wrap(err)
+		}
+		updatedChildren[i] = child.setParent(v)
+	}
+
+	stables := longestIncreasingSubsequence(matches)
+	var next UI
+	for i := len(updatedChildren) - 1; i >= 0; i-- {
+		child := updatedChildren[i]
+		if !stables[i] {
+			if next == nil {
+				v.JSValue().appendChild(child)
+			} else {
+				v.JSValue().insertBefore(child, next)
+			}
+		}
+		next = child
+	}
+
+	return updatedChildren, nil
 }
 
 func (m nodeManager) updateHTMLAttributes(ctx Context, v HTML, newAttrs attributes) {
@@ -538,7 +987,13 @@ func (m nodeManager) updateComponent(ctx Context, v, new Composer) (UI, error) {
 	}
 
 	if updater, ok := v.(Updater); ok {
-		updater.OnUpdate(ctx)
+		ctx := m.componentContext(ctx, v)
+		if err := m.call(ctx, func() { updater.OnUpdate(ctx) }); err != nil {
+			return m.updateErrorFallback(ctx, v, errors.New("updating component failed").
+				WithTag("type", reflect.TypeOf(v)).
+				WithTag("depth", v.depth()).
+				Wrap(err))
+		}
 	}
 
 	ctx.removeComponentUpdate(v)
@@ -547,47 +1002,147 @@ func (m nodeManager) updateComponent(ctx Context, v, new Composer) (UI, error) {
 
 // UpdateComponentRoot updates the root element of the given component.
 func (m nodeManager) UpdateComponentRoot(ctx Context, v Composer) (UI, error) {
-	ctx = m.context(ctx, v)
+	if v.fallback() != nil {
+		return v, nil
+	}
+	ctx = m.componentContext(ctx, v)
 
 	root := v.root()
-	newRoot, err := m.renderComponent(v)
+	newRoot, err := m.renderComponent(ctx, v)
 	if err != nil {
-		return nil, errors.New("rendering component failed").
+		return m.updateErrorFallback(ctx, v, errors.New("rendering component failed").
 			WithTag("type", reflect.TypeOf(v)).
 			WithTag("depth", v.depth()).
-			Wrap(err)
+			Wrap(err))
 	}
 
 	if m.CanUpdate(root, newRoot) {
 		if root, err = m.Update(ctx, root, newRoot); err != nil {
-			return nil, errors.New("updating component root failed").
+			return m.updateErrorFallback(ctx, v, errors.New("updating component root failed").
 				WithTag("type", reflect.TypeOf(v)).
 				WithTag("depth", v.depth()).
-				Wrap(err)
+				Wrap(err))
 		}
 		v.setRoot(root)
-	} else {
-		if newRoot, err = m.Mount(ctx, v.depth()+1, newRoot); err != nil {
-			return nil, errors.New("mounting component root failed").
-				WithTag("type", reflect.TypeOf(v)).
-				WithTag("depth", v.depth()).
-				Wrap(err)
-		}
+	} else if err := m.replaceComponentRoot(ctx, v, newRoot); err != nil {
+		return m.updateErrorFallback(ctx, v, err)
+	}
 
-		for parent := v.parent(); parent != nil; parent = parent.parent() {
-			if parent, isHTML := parent.(HTML); isHTML {
-				parent.JSValue().replaceChild(newRoot, root)
-				break
-			}
+	return v, nil
+}
+
+func (m nodeManager) replaceComponentRoot(ctx Context, v Composer, newRoot UI) error {
+	root := v.root()
+	newRoot, err := m.Mount(ctx, v.depth()+1, newRoot)
+	if err != nil {
+		return errors.New("mounting component root failed").
+			WithTag("type", reflect.TypeOf(v)).
+			WithTag("depth", v.depth()).
+			Wrap(err)
+	}
+
+	for parent := v.parent(); parent != nil; parent = parent.parent() {
+		if parent, isHTML := parent.(HTML); isHTML {
+			parent.JSValue().replaceChild(newRoot, root)
+			break
 		}
-		newRoot.setParent(v)
-		v.setRoot(newRoot)
-		m.Dismount(root)
+	}
+	newRoot.setParent(v)
+	v.setRoot(newRoot)
+	m.Dismount(root)
+	return nil
+}
+
+// updateErrorFallback replaces the root of the given component by its
+// fallback when it is an error boundary that catches the given error.
+// Otherwise, the error is returned.
+func (m nodeManager) updateErrorFallback(ctx Context, v Composer, err error) (UI, error) {
+	boundary, ok := v.(ErrorBoundary)
+	if !ok || v.fallback() != nil {
+		return nil, err
 	}
 
+	if err := m.replaceComponentRoot(ctx, v, m.catchError(ctx, boundary, err)); err != nil {
+		return nil, errors.New("mounting error fallback failed").
+			WithTag("type", reflect.TypeOf(v)).
+			WithTag("depth", v.depth()).
+			Wrap(err)
+	}
 	return v, nil
 }
 
+// CatchError reports the given error, which occurred in the given component,
+// to the nearest error boundary, which then displays its fallback. It returns
+// false when the error is not caught by an error boundary.
+func (m nodeManager) CatchError(ctx Context, v Composer, err error) bool {
+	ctx.componentScope = nil
+	ctx = m.componentContext(ctx, v)
+
+	boundary, ok := ctx.componentScope.errorBoundary()
+	if !ok || !boundary.Mounted() {
+		return false
+	}
+
+	ctx.componentScope = nil
+	if _, err := m.updateErrorFallback(m.componentContext(ctx, boundary), boundary, err); err != nil {
+		Log(errors.New("displaying error fallback failed").
+			WithTag("boundary-type", reflect.TypeOf(boundary)).
+			Wrap(err))
+	}
+	return true
+}
+
+// catchError reports the given error to the given error boundary and returns
+// the fallback to display in place of its content.
+func (m nodeManager) catchError(ctx Context, boundary ErrorBoundary, err error) UI {
+	path := errors.Tag(err, "component-path")
+	if path == nil {
+		path = ctx.componentScope.path()
+	}
+	err = errors.New("error boundary caught a failure").
+		WithTag("boundary-type", reflect.TypeOf(boundary)).
+		WithTag("component-path", path).
+		Wrap(err)
+	Log(err)
+
+	var fallback UI = Text("")
+	if v := FilterUIElems(boundary.OnError(m.context(ctx, boundary), err)); len(v) != 0 {
+		fallback = v[0]
+	}
+	boundary.setFallback(fallback)
+	return fallback
+}
+
+// call calls the given function on behalf of the component at the top of the
+// context component scope. When the component is within an error boundary,
+// a panic is recovered and returned as an error.
+func (m nodeManager) call(ctx Context, f func()) (err error) {
+	if _, ok := ctx.componentScope.errorBoundary(); !ok {
+		f()
+		return nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New("component panicked").
+				WithTag("component-path", ctx.componentScope.path()).
+				WithTag("panic", fmt.Sprint(r))
+		}
+	}()
+	f()
+	return nil
+}
+
+// dispatchLifecycle dispatches the given lifecycle method of the given
+// component. A panic is caught by the nearest error boundary.
+func (m nodeManager) dispatchLifecycle(ctx Context, v Composer, f func(Context)) {
+	ctx.Dispatch(func(ctx Context) {
+		if err := m.call(ctx, func() { f(ctx) }); err != nil {
+			m.CatchError(ctx, v, err)
+		}
+	})
+}
+
 func (m nodeManager) updateRawHTML(ctx Context, v, new *raw) (UI, error) {
 	if v.value == new.value {
 		return v, nil
@@ -619,6 +1174,32 @@ func (m nodeManager) context(ctx Context, v UI) Context {
 	return ctx
 }
 
+// componentContext returns a context for the given component, where the
+// component is pushed on top of the component scope. When the context has no
+// component scope, it is built from the ancestors of the component.
+func (m nodeManager) componentContext(ctx Context, v Composer) Context {
+	ctx = m.context(ctx, v)
+
+	scope := ctx.componentScope
+	if scope != nil && scope.component == v {
+		return ctx
+	}
+	if scope == nil {
+		var ancestors []Composer
+		for parent := v.parent(); parent != nil; parent = parent.parent() {
+			if c, ok := parent.(Composer); ok {
+				ancestors = append(ancestors, c)
+			}
+		}
+		for i := len(ancestors) - 1; i >= 0; i-- {
+			scope = &componentScope{component: ancestors[i], parent: scope}
+		}
+	}
+
+	ctx.componentScope = &componentScope{component: v, parent: scope}
+	return ctx
+}
+
 // NotifyComponentEvent traverses a UI element tree to propagate a component
 // event, activating pertinent component handlers and potentially enqueuing
 // component updates as needed.
@@ -632,31 +1213,56 @@ func (m nodeManager) NotifyComponentEvent(ctx Context, root UI, event any) {
 		}
 
 	case Composer:
+		ctx = m.componentContext(ctx, element)
 		switch event.(type) {
 		case nav:
 			if navigator, ok := element.(Navigator); ok {
-				ctx.Dispatch(navigator.OnNav)
+				m.dispatchLifecycle(ctx, element, navigator.OnNav)
 			}
 
 		case appUpdate:
 			if appUpdater, ok := element.(AppUpdater); ok {
-				ctx.Dispatch(appUpdater.OnAppUpdate)
+				m.dispatchLifecycle(ctx, element, appUpdater.OnAppUpdate)
 			}
 
 		case appInstallChange:
 			if appInstaller, ok := element.(AppInstaller); ok {
-				ctx.Dispatch(appInstaller.OnAppInstallChange)
+				m.dispatchLifecycle(ctx, element, appInstaller.OnAppInstallChange)
 			}
 
 		case resize:
 			if resizer, ok := element.(Resizer); ok {
-				ctx.Dispatch(resizer.OnResize)
+				m.dispatchLifecycle(ctx, element, resizer.OnResize)
 			}
 		}
 		m.NotifyComponentEvent(ctx, element.root(), event)
 	}
 }
 
+// NotifyBeforeLeave traverses a UI element tree to let the components
+// implementing BeforeLeaver intercept the given navigation. The traversal
+// stops as soon as the navigation is cancelled or redirected.
+func (m nodeManager) NotifyBeforeLeave(ctx Context, root UI, n *Navigation) {
+	if n.cancelled {
+		return
+	}
+
+	ctx = m.context(ctx, root)
+
+	switch element := root.(type) {
+	case HTML:
+		for _, child := range element.body() {
+			m.NotifyBeforeLeave(ctx, child, n)
+		}
+
+	case Composer:
+		if leaver, ok := element.(BeforeLeaver); ok {
+			leaver.OnBeforeLeave(ctx, n)
+		}
+		m.NotifyBeforeLeave(ctx, element.root(), n)
+	}
+}
+
 // Encode transforms the provided UI element into its HTML byte slice
 // representation. This allows for the conversion of in-memory UI structures
 // into a format suitable for server rendering.
@@ -750,9 +1356,16 @@ func (m nodeManager) encodeHTMLAttribute(ctx Context, w *bytes.Buffer, name, val
 }
 
 func (m nodeManager) encodeComponent(ctx Context, w *bytes.Buffer, depth int, v Composer) {
+	if ctx.encodePlaceholder != nil {
+		if placeholder, ok := ctx.encodePlaceholder(v); ok {
+			m.encode(ctx, w, depth, placeholder)
+			return
+		}
+	}
+
 	root := v.root()
 	if root == nil {
-		root, _ = m.renderComponent(v)
+		root, _ = m.renderComponent(ctx, v)
 	}
 	if root != nil {
 		m.encode(ctx, w, depth, root)
@@ -795,6 +1408,37 @@ func canUpdateValue(v, new reflect.Value) bool {
 	}
 }
 
+// componentScope is a stack of the components enclosing a UI element, from
+// the closest to the root.
+type componentScope struct {
+	component Composer
+	parent    *componentScope
+}
+
+// errorBoundary returns the nearest error boundary that does not display a
+// fallback.
+func (s *componentScope) errorBoundary() (ErrorBoundary, bool) {
+	for ; s != nil; s = s.parent {
+		if boundary, ok := s.component.(ErrorBoundary); ok && boundary.fallback() == nil {
+			return boundary, true
+		}
+	}
+	return nil, false
+}
+
+// path returns the types of the components in the scope, from the root to the
+// closest, separated by " > ".
+func (s *componentScope) path() string {
+	var types []string
+	for ; s != nil; s = s.parent {
+		types = append(types, reflect.TypeOf(s.component).String())
+	}
+	for i, j := 0, len(types)-1; i < j; i, j = i+1, j-1 {
+		types[i], types[j] = types[j], types[i]
+	}
+	return strings.Join(types, " > ")
+}
+
 func component(v UI) (Composer, bool) {
 	for element := v; element != nil; element = element.parent() {
 		if component, ok := element.(Composer); ok {
@@ -803,3 +1447,90 @@ func component(v UI) (Composer, bool) {
 	}
 	return nil, false
 }
+
+func keyOf(v UI) any {
+	var key any
+	switch v := v.(type) {
+	case HTML:
+		key = v.key()
+
+	case Composer:
+		key = v.key()
+	}
+
+	if key == nil || !reflect.TypeOf(key).Comparable() {
+		return nil
+	}
+	return key
+}
+
+// longestIncreasingSubsequence reports, for each index of the given sequence,
+// whether its value is part of a longest strictly increasing subsequence.
+// Negative values are ignored and are never reported.
+func longestIncreasingSubsequence(v []int) []bool {
+	var tails []int
+	predecessors := make([]int, len(v))
+	for i, n := range v {
+		predecessors[i] = -1
+		if n < 0 {
+			continue
+		}
+
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if v[tails[mid]] < n {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+
+		if lo > 0 {
+			predecessors[i] = tails[lo-1]
+		}
+		if lo == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[lo] = i
+		}
+	}
+
+	res := make([]bool, len(v))
+	if len(tails) == 0 {
+		return res
+	}
+	for i := tails[len(tails)-1]; i >= 0; i = predecessors[i] {
+		res[i] = true
+	}
+	return res
+}
+
+// DOM node types.
+const (
+	elementNodeType = 1
+	textNodeType    = 3
+	commentNodeType = 8
+)
+
+// nodeType returns the DOM node type of the given value, or 0 when the value
+// is not a node.
+func nodeType(v Value) int {
+	if v == nil || v.IsNull() || v.IsUndefined() {
+		return 0
+	}
+	return v.Get("nodeType").Int()
+}
+
+func ignorableNode(v Value) bool {
+	switch nodeType(v) {
+	case commentNodeType:
+		return true
+
+	case textNodeType:
+		return strings.TrimSpace(v.Get("nodeValue").String()) == ""
+
+	default:
+		return false
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/maxence-charriere/go-app/v10/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -370,6 +371,222 @@ func BenchmarkNodeManagerMount(b *testing.B) {
 	}
 }
 
+func TestNodeManagerHydrate(t *testing.T) {
+	ctx := makeTestContext()
+	parent, _ := Window().createElement("div", "")
+
+	t.Run("hydrating a text without node mounts it", func(t *testing.T) {
+		var m nodeManager
+
+		text, err := m.Hydrate(ctx, 1, Text("hello"), parent, nil)
+		require.NoError(t, err)
+		require.True(t, text.Mounted())
+	})
+
+	t.Run("hydrating an empty text succeeds", func(t *testing.T) {
+		var m nodeManager
+
+		text, err := m.Hydrate(ctx, 1, Text(""), parent, nil)
+		require.NoError(t, err)
+		require.True(t, text.Mounted())
+	})
+
+	t.Run("hydrating an already mounted text returns an error", func(t *testing.T) {
+		var m nodeManager
+
+		text, err := m.Mount(ctx, 1, Text("hello"))
+		require.NoError(t, err)
+
+		_, err = m.Hydrate(ctx, 1, text, parent, nil)
+		require.Error(t, err)
+		t.Log(err)
+	})
+
+	t.Run("hydrating html without node mounts it", func(t *testing.T) {
+		var m nodeManager
+
+		div, err := m.Hydrate(ctx, 1, Div().
+			Class("hello").
+			OnClick(func(Context, Event) {}).
+			Body(
+				Span(),
+			), parent, nil)
+		require.NoError(t, err)
+		require.True(t, div.Mounted())
+		require.True(t, div.(HTML).body()[0].Mounted())
+		require.NotNil(t, div.(HTML).events()["click"].close)
+	})
+
+	t.Run("hydrating an already mounted html returns an error", func(t *testing.T) {
+		var m nodeManager
+
+		div, err := m.Mount(ctx, 1, Div())
+		require.NoError(t, err)
+
+		_, err = m.Hydrate(ctx, 1, div, parent, nil)
+		require.Error(t, err)
+		t.Log(err)
+	})
+
+	t.Run("hydrating a component succeeds", func(t *testing.T) {
+		var m nodeManager
+
+		compo, err := m.Hydrate(ctx, 1, &hello{}, parent, nil)
+		require.NoError(t, err)
+		require.True(t, compo.Mounted())
+		require.True(t, compo.(Composer).root().Mounted())
+		require.Equal(t, compo, compo.(Composer).root().parent())
+	})
+
+	t.Run("hydrating a component which renders nil returns an error", func(t *testing.T) {
+		var m nodeManager
+
+		_, err := m.Hydrate(ctx, 1, &compoWithNilRendering{}, parent, nil)
+		require.Error(t, err)
+		t.Log(err)
+	})
+
+	t.Run("hydrating html with a non mountable child returns an error", func(t *testing.T) {
+		var m nodeManager
+
+		_, err := m.Hydrate(ctx, 1, Div().Body(
+			&compoWithNilRendering{},
+		), parent, nil)
+		require.Error(t, err)
+		t.Log(err)
+	})
+
+	t.Run("hydrating an error boundary with a failing child mounts its fallback", func(t *testing.T) {
+		var m nodeManager
+
+		failing := &panicCompo{RenderPanic: true}
+		boundary := &errorBoundaryCompo{Content: Div().Body(&hello{}, failing)}
+		compo, err := m.Hydrate(ctx, 1, boundary, parent, nil)
+		require.NoError(t, err)
+		require.True(t, compo.Mounted())
+		require.Equal(t, "fallback", boundary.root().(*text).value)
+		require.Error(t, boundary.err)
+		require.False(t, failing.Mounted())
+	})
+
+	t.Run("hydrating raw html without node mounts it", func(t *testing.T) {
+		var m nodeManager
+
+		raw, err := m.Hydrate(ctx, 1, Raw("<p>hello</p>"), parent, nil)
+		require.NoError(t, err)
+		require.True(t, raw.Mounted())
+	})
+
+	t.Run("hydrating not supported element returns an error", func(t *testing.T) {
+		var m nodeManager
+
+		_, err := m.Hydrate(ctx, 1, condition{}, parent, nil)
+		require.Error(t, err)
+		t.Log(err)
+	})
+}
+
+func TestNodeManagerHydrateExistingNodes(t *testing.T) {
+	testSkipNonWasm(t)
+
+	ctx := makeTestContext()
+	prerender := func(t *testing.T, v UI) (Value, Value) {
+		var m nodeManager
+		var b bytes.Buffer
+		m.Encode(ctx, &b, v)
+
+		parent, err := Window().createElement("div", "")
+		require.NoError(t, err)
+		parent.setInnerHTML(b.String())
+		return parent, parent.firstChild()
+	}
+
+	t.Run("html element is adopted", func(t *testing.T) {
+		var m nodeManager
+		parent, node := prerender(t, Div().ID("hello").Body(Span(), Img()))
+
+		div, err := m.Hydrate(ctx, 1, Div().ID("hello").Body(Span(), Img()), parent, node)
+		require.NoError(t, err)
+		require.True(t, div.JSValue().Equal(node))
+		require.True(t, parent.firstChild().Equal(node))
+		require.True(t, div.(HTML).body()[0].JSValue().Equal(node.firstChild()))
+		require.Equal(t, 2, node.Get("childNodes").Length())
+	})
+
+	t.Run("html element attributes are patched", func(t *testing.T) {
+		var m nodeManager
+		parent, node := prerender(t, Div().Class("old").Title("hello"))
+
+		div, err := m.Hydrate(ctx, 1, Div().Class("new"), parent, node)
+		require.NoError(t, err)
+		require.True(t, div.JSValue().Equal(node))
+		require.Equal(t, "new", node.Call("getAttribute", "class").String())
+		require.False(t, node.Call("hasAttribute", "title").Bool())
+	})
+
+	t.Run("html element event handler is attached", func(t *testing.T) {
+		var m nodeManager
+		parent, node := prerender(t, Button())
+
+		clicked := false
+		button, err := m.Hydrate(ctx, 1, Button().OnClick(func(Context, Event) {
+			clicked = true
+		}), parent, node)
+		require.NoError(t, err)
+		require.True(t, button.JSValue().Equal(node))
+
+		node.Call("click")
+		require.True(t, clicked)
+	})
+
+	t.Run("merged texts are split", func(t *testing.T) {
+		var m nodeManager
+		parent, node := prerender(t, P().Body(Text("hello"), Text("world")))
+		require.Equal(t, 1, node.Get("childNodes").Length())
+		textNode := node.firstChild()
+
+		p, err := m.Hydrate(ctx, 1, P().Body(Text("hello"), Text("world")), parent, node)
+		require.NoError(t, err)
+		require.True(t, p.JSValue().Equal(node))
+
+		texts := p.(HTML).body()
+		require.True(t, texts[0].JSValue().Equal(textNode))
+		require.Equal(t, "hello", texts[0].JSValue().Get("nodeValue").String())
+		require.Equal(t, "world", texts[1].JSValue().Get("nodeValue").String())
+	})
+
+	t.Run("component root is adopted", func(t *testing.T) {
+		var m nodeManager
+		parent, node := prerender(t, &hello{})
+
+		compo, err := m.Hydrate(ctx, 1, &hello{}, parent, node)
+		require.NoError(t, err)
+		require.True(t, compo.(Composer).root().JSValue().Equal(node))
+	})
+
+	t.Run("failing component within an error boundary is replaced by the fallback", func(t *testing.T) {
+		var m nodeManager
+		parent, node := prerender(t, Div().Body(Span()))
+
+		boundary := &errorBoundaryCompo{Content: Div().Body(&panicCompo{RenderPanic: true})}
+		_, err := m.Hydrate(ctx, 1, boundary, parent, node)
+		require.NoError(t, err)
+		require.Equal(t, "fallback", boundary.root().(*text).value)
+		require.True(t, parent.firstChild().Equal(boundary.root().JSValue()))
+		require.Equal(t, 1, parent.Get("childNodes").Length())
+	})
+
+	t.Run("mismatched element is replaced", func(t *testing.T) {
+		var m nodeManager
+		parent, node := prerender(t, Span())
+
+		div, err := m.Hydrate(ctx, 1, Div(), parent, node)
+		require.NoError(t, err)
+		require.False(t, div.JSValue().Equal(node))
+		require.True(t, parent.firstChild().Equal(div.JSValue()))
+	})
+}
+
 func TestNodeManagerDismount(t *testing.T) {
 	ctx := makeTestContext()
 
@@ -442,6 +659,19 @@ func TestNodeManagerCanUpdate(t *testing.T) {
 		require.False(t, m.CanUpdate(Div(), Span()))
 	})
 
+	t.Run("elements with different keys cannot be updated", func(t *testing.T) {
+		var m nodeManager
+		require.False(t, m.CanUpdate(Div().Key(1), Div().Key(2)))
+		require.False(t, m.CanUpdate(Div().Key(1), Div()))
+		require.False(t, m.CanUpdate(Keyed(1, &hello{}), &hello{}))
+	})
+
+	t.Run("elements with same keys can be updated", func(t *testing.T) {
+		var m nodeManager
+		require.True(t, m.CanUpdate(Div().Key(1), Div().Key(1)))
+		require.True(t, m.CanUpdate(Keyed("a", &hello{}), Keyed("a", &hello{})))
+	})
+
 	t.Run("generic html elements with same tag can be updated", func(t *testing.T) {
 		var m nodeManager
 		require.True(t, m.CanUpdate(Elem("div"), Elem("div")))
@@ -729,6 +959,134 @@ func TestNodeManagerUpdate(t *testing.T) {
 		require.Empty(t, div.(HTML).body())
 	})
 
+	t.Run("update html inserts a keyed child without re-mounting siblings", func(t *testing.T) {
+		var m nodeManager
+
+		div, err := m.Mount(ctx, 1, Div().Body(
+			Keyed("b", &bar{Value: "b"}),
+			Keyed("c", &bar{Value: "c"}),
+		))
+		require.NoError(t, err)
+		b := div.(HTML).body()[0]
+		c := div.(HTML).body()[1]
+
+		div, err = m.Update(ctx, div, Div().Body(
+			Keyed("a", &bar{Value: "a"}),
+			Keyed("b", &bar{Value: "b"}),
+			Keyed("c", &bar{Value: "c"}),
+		))
+		require.NoError(t, err)
+		children := div.(HTML).body()
+		require.Len(t, children, 3)
+		require.Equal(t, "a", children[0].(*bar).Value)
+		require.True(t, children[0].Mounted())
+		require.Same(t, b, children[1])
+		require.Same(t, c, children[2])
+		require.True(t, b.Mounted())
+		require.True(t, c.Mounted())
+	})
+
+	t.Run("update html moves keyed children", func(t *testing.T) {
+		var m nodeManager
+
+		div, err := m.Mount(ctx, 1, Div().Body(
+			Span().Key(1).Text("1"),
+			Span().Key(2).Text("2"),
+			Span().Key(3).Text("3"),
+		))
+		require.NoError(t, err)
+		one := div.(HTML).body()[0]
+		three := div.(HTML).body()[2]
+
+		div, err = m.Update(ctx, div, Div().Body(
+			Span().Key(3).Text("3"),
+			Span().Key(1).Text("one"),
+		))
+		require.NoError(t, err)
+		children := div.(HTML).body()
+		require.Len(t, children, 2)
+		require.Same(t, three, children[0])
+		require.Same(t, one, children[1])
+		require.Equal(t, "one", one.(HTML).body()[0].(*text).value)
+	})
+
+	t.Run("update html removes a keyed child", func(t *testing.T) {
+		var m nodeManager
+
+		div, err := m.Mount(ctx, 1, Div().Body(
+			Keyed("a", &bar{}),
+			Keyed("b", &bar{}),
+		))
+		require.NoError(t, err)
+		a := div.(HTML).body()[0]
+		b := div.(HTML).body()[1]
+
+		div, err = m.Update(ctx, div, Div().Body(
+			Keyed("b", &bar{}),
+		))
+		require.NoError(t, err)
+		require.Len(t, div.(HTML).body(), 1)
+		require.Same(t, b, div.(HTML).body()[0])
+		require.False(t, a.Mounted())
+	})
+
+	t.Run("update html replaces a keyed child with a different type", func(t *testing.T) {
+		var m nodeManager
+
+		div, err := m.Mount(ctx, 1, Div().Body(
+			Span().Key("a"),
+		))
+		require.NoError(t, err)
+		span := div.(HTML).body()[0]
+
+		div, err = m.Update(ctx, div, Div().Body(
+			P().Key("a"),
+		))
+		require.NoError(t, err)
+		require.Len(t, div.(HTML).body(), 1)
+		require.IsType(t, P(), div.(HTML).body()[0])
+		require.False(t, span.Mounted())
+	})
+
+	t.Run("update html matches unkeyed children among keyed ones by order", func(t *testing.T) {
+		var m nodeManager
+
+		div, err := m.Mount(ctx, 1, Div().Body(
+			H1(),
+			Span().Key("a"),
+			P(),
+		))
+		require.NoError(t, err)
+		h1 := div.(HTML).body()[0]
+		p := div.(HTML).body()[2]
+
+		div, err = m.Update(ctx, div, Div().Body(
+			H1(),
+			P(),
+		))
+		require.NoError(t, err)
+		require.Len(t, div.(HTML).body(), 2)
+		require.Same(t, h1, div.(HTML).body()[0])
+		require.Same(t, p, div.(HTML).body()[1])
+	})
+
+	t.Run("update html by adding non mountable keyed child returns an error", func(t *testing.T) {
+		var m nodeManager
+
+		div, err := m.Mount(ctx, 1, Div().Body(
+			Span().Key("a"),
+		))
+		require.NoError(t, err)
+
+		div, err = m.Update(ctx, div, Div().Body(
+			Keyed("b", &compoWithNilRendering{}),
+			Span().Key("a"),
+		))
+		require.Error(t, err)
+		require.Zero(t, div)
+		t.Log(err)
+	})
+
 	t.Run("update component updates a field", func(t *testing.T) {
 		var m nodeManager
 
@@ -957,6 +1315,77 @@ func TestNodeManagerNotifyComponentEvent(t *testing.T) {
 	})
 }
 
+func TestNodeManagerErrorBoundary(t *testing.T) {
+	t.Run("render failure while mounting is caught", func(t *testing.T) {
+		e := newTestEngine()
+		boundary := &errorBoundaryCompo{Content: &panicCompo{RenderPanic: true}}
+		err := e.Load(boundary)
+		require.NoError(t, err)
+		e.ConsumeAll()
+
+		require.Equal(t, "fallback", boundary.root().(*text).value)
+		require.Error(t, boundary.err)
+		require.Equal(t, "*app.errorBoundaryCompo > *app.panicCompo", errors.Tag(boundary.err, "component-path"))
+	})
+
+	t.Run("partially mounted content of a caught failure is dismounted", func(t *testing.T) {
+		e := newTestEngine()
+		sibling := &hello{}
+		failing := &panicCompo{RenderPanic: true}
+		boundary := &errorBoundaryCompo{Content: Div().Body(sibling, failing)}
+		err := e.Load(boundary)
+		require.NoError(t, err)
+		e.ConsumeAll()
+
+		require.Equal(t, "fallback", boundary.root().(*text).value)
+		require.False(t, sibling.Mounted())
+		require.False(t, failing.Mounted())
+	})
+
+	t.Run("render failure while updating is caught", func(t *testing.T) {
+		e := newTestEngine()
+		failing := &panicCompo{}
+		boundary := &errorBoundaryCompo{Content: failing}
+		sibling := &hello{}
+		err := e.Load(&layoutCompo{content: Div().Body(boundary, sibling)})
+		require.NoError(t, err)
+		e.ConsumeAll()
+		require.IsType(t, &htmlDiv{}, boundary.root())
+
+		failing.RenderPanic = true
+		e.updates.Add(failing, 1)
+		e.ConsumeAll()
+		require.Equal(t, "fallback", boundary.root().(*text).value)
+		require.False(t, failing.Mounted())
+		require.Equal(t, "*app.layoutCompo > *app.errorBoundaryCompo > *app.panicCompo", errors.Tag(boundary.err, "component-path"))
+
+		sibling.Greeting = "bye"
+		e.updates.Add(sibling, 1)
+		e.ConsumeAll()
+		require.True(t, sibling.Mounted())
+	})
+
+	t.Run("lifecycle failure is caught", func(t *testing.T) {
+		e := newTestEngine()
+		boundary := &errorBoundaryCompo{Content: &panicCompo{NavPanic: true}}
+		err := e.Load(boundary)
+		require.NoError(t, err)
+		e.ConsumeAll()
+
+		e.nodes.NotifyComponentEvent(e.baseContext(), e.body, nav{})
+		e.ConsumeAll()
+		require.Equal(t, "fallback", boundary.root().(*text).value)
+		require.Error(t, boundary.err)
+	})
+
+	t.Run("failure without error boundary panics", func(t *testing.T) {
+		e := newTestEngine()
+		require.Panics(t, func() {
+			e.Load(&panicCompo{RenderPanic: true})
+		})
+	})
+}
+
 func TestNodeManagerEncode(t *testing.T) {
 	t.Run("encode indent with no depth", func(t *testing.T) {
 		var m nodeManager
@@ -1247,3 +1676,43 @@ func TestComponent(t *testing.T) {
 		require.Nil(t, c)
 	})
 }
+
+func TestLongestIncreasingSubsequence(t *testing.T) {
+	utests := []struct {
+		scenario string
+		in       []int
+		out      []bool
+	}{
+		{
+			scenario: "empty",
+			in:       nil,
+			out:      []bool{},
+		},
+		{
+			scenario: "sorted",
+			in:       []int{0, 1, 2},
+			out:      []bool{true, true, true},
+		},
+		{
+			scenario: "item inserted at the top",
+			in:       []int{-1, 0, 1},
+			out:      []bool{false, true, true},
+		},
+		{
+			scenario: "last item moved to the top",
+			in:       []int{2, 0, 1},
+			out:      []bool{false, true, true},
+		},
+		{
+			scenario: "reversed",
+			in:       []int{2, 1, 0},
+			out:      []bool{false, false, true},
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			require.Equal(t, u.out, longestIncreasingSubsequence(u.in))
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package app
 
+import "net/http"
+
 var (
 	// NotFound is the ui element that is displayed when a request is not
 	// routed.
@@ -11,6 +13,10 @@ type notFound struct {
 	Icon string
 }
 
+func (n *notFound) OnPreRender(ctx Context) {
+	ctx.Page().SetStatus(http.StatusNotFound)
+}
+
 func (n *notFound) OnMount(Context) {
 	links := Window().Get("document").Call("getElementsByTagName", "link")
 
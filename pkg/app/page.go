@@ -1,6 +1,7 @@
 package app
 
 import (
+	"net/http"
 	"net/url"
 	"strings"
 )
@@ -65,6 +66,36 @@ type Page interface {
 
 	// Set the Twitter card.
 	SetTwitterCard(v TwitterCard)
+
+	// Returns the HTTP status code of the page.
+	Status() int
+
+	// Sets the HTTP status code of the page.
+	//
+	// Only works when pre-rendering.
+	SetStatus(code int)
+
+	// Sets an HTTP header of the page response, replacing the values
+	// previously set for the same header.
+	//
+	// Only works when pre-rendering.
+	SetHeader(name, value string)
+
+	// Adds a value to an HTTP header of the page response, keeping the values
+	// previously set for the same header. It is intended for headers that can
+	// be repeated, such as Set-Cookie.
+	//
+	// Only works when pre-rendering.
+	AddHeader(name, value string)
+
+	// Redirects to the given URL with the given HTTP status code, which is
+	// usually http.StatusMovedPermanently or http.StatusFound. Codes that are
+	// not redirections are replaced by http.StatusFound.
+	//
+	// When pre-rendering, the server replies with a redirection instead of
+	// the page. In the browser, the current page URL is replaced by the given
+	// one.
+	Redirect(rawURL string, code int)
 }
 
 type requestPage struct {
@@ -82,6 +113,9 @@ type requestPage struct {
 	width          int
 	height         int
 	twitterCardMap map[string]string
+	status         int
+	header         http.Header
+	redirectURL    string
 }
 
 func makeRequestPage(origin *url.URL, resolveURL func(string) string) requestPage {
@@ -173,6 +207,39 @@ func (p *requestPage) SetTwitterCard(v TwitterCard) {
 	p.twitterCardMap = v.toMap()
 }
 
+func (p *requestPage) Status() int {
+	if p.status == 0 {
+		return http.StatusOK
+	}
+	return p.status
+}
+
+func (p *requestPage) SetStatus(code int) {
+	p.status = code
+}
+
+func (p *requestPage) SetHeader(name, value string) {
+	if p.header == nil {
+		p.header = make(http.Header)
+	}
+	p.header.Set(name, value)
+}
+
+func (p *requestPage) AddHeader(name, value string) {
+	if p.header == nil {
+		p.header = make(http.Header)
+	}
+	p.header.Add(name, value)
+}
+
+func (p *requestPage) Redirect(rawURL string, code int) {
+	if code < 300 || code > 399 {
+		code = http.StatusFound
+	}
+	p.redirectURL = rawURL
+	p.status = code
+}
+
 type browserPage struct {
 	resolveURL func(string) string
 }
@@ -283,6 +350,23 @@ func (p browserPage) SetTwitterCard(v TwitterCard) {
 	}
 }
 
+func (p browserPage) Status() int {
+	return http.StatusOK
+}
+
+func (p browserPage) SetStatus(code int) {
+}
+
+func (p browserPage) SetHeader(name, value string) {
+}
+
+func (p browserPage) AddHeader(name, value string) {
+}
+
+func (p browserPage) Redirect(rawURL string, code int) {
+	Window().Get("location").Call("replace", rawURL)
+}
+
 func (p browserPage) metaByName(v string) Value {
 	meta := Window().
 		Get("document").
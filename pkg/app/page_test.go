@@ -1,6 +1,7 @@
 package app
 
 import (
+	"net/http"
 	"net/url"
 	"testing"
 
@@ -57,4 +58,22 @@ func testPage(t *testing.T, p Page) {
 	require.NotZero(t, h)
 
 	p.SetTwitterCard(TwitterCard{Card: "summary"})
+
+	require.Equal(t, http.StatusOK, p.Status())
+	p.SetStatus(http.StatusNotFound)
+	require.Equal(t, http.StatusNotFound, p.Status())
+
+	p.SetHeader("X-Test", "test")
+	p.AddHeader("X-Test", "test")
+}
+
+func TestRequestPageHeader(t *testing.T) {
+	var p requestPage
+
+	p.AddHeader("Set-Cookie", "session=42")
+	p.AddHeader("Set-Cookie", "theme=dark")
+	require.Equal(t, []string{"session=42", "theme=dark"}, p.header.Values("Set-Cookie"))
+
+	p.SetHeader("Set-Cookie", "lang=fr")
+	require.Equal(t, []string{"lang=fr"}, p.header.Values("Set-Cookie"))
 }
@@ -2,13 +2,19 @@ package app
 
 import (
 	"regexp"
+	"sort"
+	"strings"
 	"sync"
+
+	"github.com/maxence-charriere/go-app/v10/pkg/errors"
 )
 
 type router struct {
 	mu               sync.RWMutex
 	routes           map[string]func() Composer
+	routesWithParams []paramRoute
 	routesWithRegexp []regexpRoute
+	layouts          []layoutRoute
 }
 
 func makeRouter() router {
@@ -21,6 +27,14 @@ func (r *router) route(path string, newComponent func() Composer) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if isParamRoutePath(path) {
+		r.routesWithParams = append(r.routesWithParams, paramRoute{
+			pattern:      path,
+			segments:     parseRouteSegments(path),
+			newComponent: newComponent,
+		})
+		return
+	}
 	r.routes[path] = newComponent
 }
 
@@ -29,46 +43,272 @@ func (r *router) routeWithRegexp(pattern string, newComponent func() Composer) {
 	defer r.mu.Unlock()
 
 	r.routesWithRegexp = append(r.routesWithRegexp, regexpRoute{
+		pattern:      pattern,
 		regexp:       regexp.MustCompile(pattern),
 		newComponent: newComponent,
 	})
 }
 
-func (r *router) routed(path string) bool {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+func (r *router) routeLayout(prefix string, newLayout func() Layout) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	if _, routed := r.routes[path]; routed {
-		return true
+	prefix = normalizeLayoutPrefix(prefix)
+	for i, l := range r.layouts {
+		if l.prefix == prefix {
+			r.layouts[i].newLayout = newLayout
+			return
+		}
 	}
 
-	for _, rwr := range r.routesWithRegexp {
-		if rwr.regexp.MatchString(path) {
-			return true
+	r.layouts = append(r.layouts, layoutRoute{
+		prefix:    prefix,
+		newLayout: newLayout,
+	})
+	sort.SliceStable(r.layouts, func(a, b int) bool {
+		return len(r.layouts[a].prefix) < len(r.layouts[b].prefix)
+	})
+}
+
+// layoutsOf returns the layouts that wrap the given path, from the outermost to
+// the innermost.
+func (r *router) layoutsOf(path string) []layoutRoute {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var layouts []layoutRoute
+	for _, l := range r.layouts {
+		if l.wraps(path) {
+			layouts = append(layouts, l)
 		}
 	}
+	return layouts
+}
+
+func (r *router) routed(path string) bool {
+	_, routed := r.match(path)
+	return routed
+}
 
-	return false
+// routePattern returns the path or pattern of the route that matches the given
+// path, as given when the route was registered.
+func (r *router) routePattern(path string) (string, bool) {
+	m, routed := r.match(path)
+	return m.pattern, routed
+}
+
+// createComponent creates the component routed to the given path. It returns
+// the parameters extracted from the path when the path matches a route with
+// parameters.
+func (r *router) createComponent(path string) (Composer, map[string]string, bool) {
+	m, routed := r.match(path)
+	if !routed {
+		return nil, nil, false
+	}
+	return m.newComponent(), m.params, true
 }
 
-func (r *router) createComponent(path string) (Composer, bool) {
+// match looks for the route that matches the given path. Static routes take
+// precedence over routes with parameters, which take precedence over routes
+// with regular expressions.
+func (r *router) match(path string) (routeMatch, bool) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	if newComponent, routed := r.routes[path]; routed {
-		return newComponent(), true
+		return routeMatch{
+			pattern:      path,
+			newComponent: newComponent,
+		}, true
+	}
+
+	var best *paramRoute
+	var bestParams map[string]string
+	for i := range r.routesWithParams {
+		route := &r.routesWithParams[i]
+		params, ok := route.match(path)
+		if !ok {
+			continue
+		}
+		if best == nil || route.moreSpecificThan(best) {
+			best = route
+			bestParams = params
+		}
+	}
+	if best != nil {
+		return routeMatch{
+			pattern:      best.pattern,
+			newComponent: best.newComponent,
+			params:       bestParams,
+		}, true
 	}
 
 	for _, rwr := range r.routesWithRegexp {
 		if rwr.regexp.MatchString(path) {
-			return rwr.newComponent(), true
+			return routeMatch{
+				pattern:      rwr.pattern,
+				newComponent: rwr.newComponent,
+			}, true
 		}
 	}
 
-	return nil, false
+	return routeMatch{}, false
+}
+
+type routeMatch struct {
+	pattern      string
+	newComponent func() Composer
+	params       map[string]string
+}
+
+type layoutRoute struct {
+	prefix    string
+	newLayout func() Layout
+}
+
+func (r layoutRoute) wraps(path string) bool {
+	return r.prefix == "/" ||
+		path == r.prefix ||
+		strings.HasPrefix(path, r.prefix+"/")
+}
+
+func normalizeLayoutPrefix(v string) string {
+	return "/" + strings.Trim(v, "/")
 }
 
 type regexpRoute struct {
+	pattern      string
 	regexp       *regexp.Regexp
 	newComponent func() Composer
 }
+
+// Kinds of route segments, ordered from the most to the least specific.
+const (
+	staticSegment routeSegmentKind = iota
+	regexpParamSegment
+	paramSegment
+	wildcardSegment
+)
+
+type routeSegmentKind int
+
+type routeSegment struct {
+	kind   routeSegmentKind
+	value  string
+	regexp *regexp.Regexp
+}
+
+type paramRoute struct {
+	pattern      string
+	segments     []routeSegment
+	newComponent func() Composer
+}
+
+func (r *paramRoute) match(path string) (map[string]string, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	params := make(map[string]string)
+
+	for i, segment := range r.segments {
+		if segment.kind == wildcardSegment {
+			params[segment.value] = strings.Join(parts[i:], "/")
+			return params, true
+		}
+
+		if i >= len(parts) {
+			return nil, false
+		}
+		part := parts[i]
+
+		switch segment.kind {
+		case staticSegment:
+			if part != segment.value {
+				return nil, false
+			}
+
+		case regexpParamSegment:
+			if !segment.regexp.MatchString(part) {
+				return nil, false
+			}
+			params[segment.value] = part
+
+		case paramSegment:
+			if part == "" {
+				return nil, false
+			}
+			params[segment.value] = part
+		}
+	}
+
+	if len(parts) != len(r.segments) {
+		return nil, false
+	}
+	return params, true
+}
+
+func (r *paramRoute) moreSpecificThan(o *paramRoute) bool {
+	for i := 0; i < len(r.segments) && i < len(o.segments); i++ {
+		if kind, otherKind := r.segments[i].kind, o.segments[i].kind; kind != otherKind {
+			return kind < otherKind
+		}
+	}
+	return len(r.segments) > len(o.segments)
+}
+
+func isParamRoutePath(path string) bool {
+	return strings.Contains(path, "{")
+}
+
+func parseRouteSegments(path string) []routeSegment {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	segments := make([]routeSegment, len(parts))
+
+	for i, part := range parts {
+		if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") {
+			if strings.ContainsAny(part, "{}") {
+				panic(errors.New("route segment is not a valid parameter").
+					WithTag("path", path).
+					WithTag("segment", part))
+			}
+			segments[i] = routeSegment{
+				kind:  staticSegment,
+				value: part,
+			}
+			continue
+		}
+
+		param := strings.TrimSuffix(strings.TrimPrefix(part, "{"), "}")
+		name, pattern, hasPattern := strings.Cut(param, ":")
+		switch {
+		case name == "", name == "...":
+			panic(errors.New("route parameter has no name").
+				WithTag("path", path).
+				WithTag("segment", part))
+
+		case strings.HasSuffix(name, "...") && !hasPattern:
+			if i != len(parts)-1 {
+				panic(errors.New("route wildcard parameter is not the last segment").
+					WithTag("path", path).
+					WithTag("segment", part))
+			}
+			segments[i] = routeSegment{
+				kind:  wildcardSegment,
+				value: strings.TrimSuffix(name, "..."),
+			}
+
+		case hasPattern:
+			segments[i] = routeSegment{
+				kind:   regexpParamSegment,
+				value:  name,
+				regexp: regexp.MustCompile("^(?:" + pattern + ")$"),
+			}
+
+		default:
+			segments[i] = routeSegment{
+				kind:  paramSegment,
+				value: name,
+			}
+		}
+	}
+
+	return segments
+}
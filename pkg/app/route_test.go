@@ -21,6 +21,7 @@ func TestRoutes(t *testing.T) {
 		createRoutes func(*router)
 		path         string
 		expected     Composer
+		params       map[string]string
 		notFound     bool
 	}{
 		{
@@ -107,6 +108,115 @@ func TestRoutes(t *testing.T) {
 			},
 			notFound: true,
 		},
+		{
+			scenario: "path with parameter is routed",
+			path:     "/users/42",
+			createRoutes: func(r *router) {
+				r.route("/users/{id}", NewZeroComponentFactory(&routeCompo{}))
+			},
+			expected: &routeCompo{},
+			params:   map[string]string{"id": "42"},
+		},
+		{
+			scenario: "path with parameters is routed",
+			path:     "/users/42/posts/hello-world",
+			createRoutes: func(r *router) {
+				r.route("/users/{id}/posts/{slug:[a-z-]+}", NewZeroComponentFactory(&routeCompo{}))
+			},
+			expected: &routeCompo{},
+			params:   map[string]string{"id": "42", "slug": "hello-world"},
+		},
+		{
+			scenario: "path not satisfying parameter regexp is not routed",
+			path:     "/users/42/posts/Hello_World",
+			createRoutes: func(r *router) {
+				r.route("/users/{id}/posts/{slug:[a-z-]+}", NewZeroComponentFactory(&routeCompo{}))
+			},
+			notFound: true,
+		},
+		{
+			scenario: "path with empty parameter is not routed",
+			path:     "/users/",
+			createRoutes: func(r *router) {
+				r.route("/users/{id}", NewZeroComponentFactory(&routeCompo{}))
+			},
+			notFound: true,
+		},
+		{
+			scenario: "path with extra segment is not routed",
+			path:     "/users/42/posts",
+			createRoutes: func(r *router) {
+				r.route("/users/{id}", NewZeroComponentFactory(&routeCompo{}))
+			},
+			notFound: true,
+		},
+		{
+			scenario: "path with rest parameter is routed",
+			path:     "/files/foo/bar/baz.png",
+			createRoutes: func(r *router) {
+				r.route("/files/{path...}", NewZeroComponentFactory(&routeCompo{}))
+			},
+			expected: &routeCompo{},
+			params:   map[string]string{"path": "foo/bar/baz.png"},
+		},
+		{
+			scenario: "path with empty rest parameter is routed",
+			path:     "/files/",
+			createRoutes: func(r *router) {
+				r.route("/files/{path...}", NewZeroComponentFactory(&routeCompo{}))
+			},
+			expected: &routeCompo{},
+			params:   map[string]string{"path": ""},
+		},
+		{
+			scenario: "static path takes priority over path with parameter",
+			path:     "/users/new",
+			createRoutes: func(r *router) {
+				r.route("/users/{id}", NewZeroComponentFactory(&routeWithRegexpCompo{}))
+				r.route("/users/new", NewZeroComponentFactory(&routeCompo{}))
+			},
+			expected: &routeCompo{},
+		},
+		{
+			scenario: "static segment takes priority over parameter",
+			path:     "/users/new/edit",
+			createRoutes: func(r *router) {
+				r.route("/users/{id}/edit", NewZeroComponentFactory(&routeWithRegexpCompo{}))
+				r.route("/users/new/{action}", NewZeroComponentFactory(&routeCompo{}))
+			},
+			expected: &routeCompo{},
+			params:   map[string]string{"action": "edit"},
+		},
+		{
+			scenario: "constrained parameter takes priority over parameter",
+			path:     "/users/42",
+			createRoutes: func(r *router) {
+				r.route("/users/{name}", NewZeroComponentFactory(&routeWithRegexpCompo{}))
+				r.route("/users/{id:[0-9]+}", NewZeroComponentFactory(&routeCompo{}))
+			},
+			expected: &routeCompo{},
+			params:   map[string]string{"id": "42"},
+		},
+		{
+			scenario: "parameter takes priority over rest parameter",
+			path:     "/files/foo",
+			createRoutes: func(r *router) {
+				r.route("/files/{path...}", NewZeroComponentFactory(&routeWithRegexpCompo{}))
+				r.route("/files/{name}", NewZeroComponentFactory(&routeCompo{}))
+			},
+			expected: &routeCompo{},
+			params:   map[string]string{"name": "foo"},
+		},
+		{
+			scenario: "path with parameter takes priority over regexp",
+			path:     "/users/42",
+			createRoutes: func(r *router) {
+				r.routeWithRegexp("^/users/.*$", NewZeroComponentFactory(&routeWithRegexpCompo{}))
+				r.route("/users/{id}", NewZeroComponentFactory(&routeCompo{}))
+			},
+			expected: &routeCompo{},
+			params:   map[string]string{"id": "42"},
+		},
 	}
 
 	for _, u := range utests {
@@ -120,8 +230,9 @@ func TestRoutes(t *testing.T) {
 				routed := r.routed(u.path)
 				require.False(t, routed)
 
-				compo, routed := r.createComponent(u.path)
+				compo, params, routed := r.createComponent(u.path)
 				require.Nil(t, compo)
+				require.Nil(t, params)
 				require.False(t, routed)
 				return
 			}
@@ -129,10 +240,76 @@ func TestRoutes(t *testing.T) {
 			routed := r.routed(u.path)
 			require.True(t, routed)
 
-			compo, routed := r.createComponent(u.path)
+			compo, params, routed := r.createComponent(u.path)
 			require.True(t, routed)
 			require.NotNil(t, compo)
 			require.Equal(t, reflect.TypeOf(u.expected), reflect.TypeOf(compo))
+			if u.params != nil {
+				require.Equal(t, u.params, params)
+			}
+		})
+	}
+}
+
+func TestRouteWithInvalidParams(t *testing.T) {
+	utests := []struct {
+		scenario string
+		path     string
+	}{
+		{
+			scenario: "parameter without name",
+			path:     "/users/{}",
+		},
+		{
+			scenario: "rest parameter without name",
+			path:     "/files/{...}",
+		},
+		{
+			scenario: "rest parameter not at the end",
+			path:     "/files/{path...}/edit",
+		},
+		{
+			scenario: "parameter within a segment",
+			path:     "/users/user-{id}",
+		},
+		{
+			scenario: "parameter with invalid regexp",
+			path:     "/users/{id:[0-9}",
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			r := makeRouter()
+			require.Panics(t, func() {
+				r.route(u.path, NewZeroComponentFactory(&routeCompo{}))
+			})
 		})
 	}
 }
+
+func TestRouteLayouts(t *testing.T) {
+	newLayout := func() Layout { return nil }
+
+	r := makeRouter()
+	r.routeLayout("/docs/", newLayout)
+	r.routeLayout("/", newLayout)
+	r.routeLayout("docs/api", newLayout)
+
+	prefixes := func(path string) []string {
+		var res []string
+		for _, l := range r.layoutsOf(path) {
+			res = append(res, l.prefix)
+		}
+		return res
+	}
+
+	require.Equal(t, []string{"/"}, prefixes("/"))
+	require.Equal(t, []string{"/"}, prefixes("/docsx"))
+	require.Equal(t, []string{"/", "/docs"}, prefixes("/docs"))
+	require.Equal(t, []string{"/", "/docs"}, prefixes("/docs/getting-started"))
+	require.Equal(t, []string{"/", "/docs", "/docs/api"}, prefixes("/docs/api/route"))
+
+	r.routeLayout("/docs", newLayout)
+	require.Len(t, r.layouts, 3)
+}
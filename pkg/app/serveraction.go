@@ -0,0 +1,223 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/maxence-charriere/go-app/v10/pkg/errors"
+)
+
+// HandleOnServer registers the provided handler for a specific action name, to
+// be executed on the server. When that action is triggered in the web browser,
+// it is sent to the server through an endpoint served by the Handler, where
+// the handler executes with a Context bound to the HTTP request.
+//
+// The actions posted and the states set by the handler with its Context are
+// sent back to the web browser, where they are respectively posted and set.
+// Action and state values are transmitted in JSON: action values are received
+// as json.RawMessage, and state values are decoded into the receivers of the
+// states.
+//
+// Example:
+//
+//	app.HandleOnServer("/users/save", func(ctx app.Context, a app.Action) {
+//	    var u User
+//	    if err := json.Unmarshal(a.Value.(json.RawMessage), &u); err != nil {
+//	        ctx.NewActionWithValue("/users/save/error", err.Error())
+//	        return
+//	    }
+//	    ctx.SetState("/users/"+u.ID, u)
+//	})
+func HandleOnServer(actionName string, h ActionHandler) {
+	serverActionHandlers[actionName] = h
+	if IsClient {
+		actionHandlers[actionName] = forwardServerAction
+	}
+}
+
+var serverActionHandlers = make(map[string]ActionHandler)
+
+const (
+	serverActionsPath       = "/goapp/actions"
+	maxServerActionBodySize = 1 << 20
+)
+
+// serverAction is the JSON representation of an action transmitted between
+// the web browser and the server.
+type serverAction struct {
+	Name  string
+	Value json.RawMessage `json:",omitempty"`
+	Tags  Tags            `json:",omitempty"`
+}
+
+func makeServerAction(a Action) (serverAction, error) {
+	var value json.RawMessage
+	if a.Value != nil {
+		b, err := json.Marshal(a.Value)
+		if err != nil {
+			return serverAction{}, errors.New("encoding action value failed").
+				WithTag("action", a.Name).
+				Wrap(err)
+		}
+		value = b
+	}
+
+	return serverAction{
+		Name:  a.Name,
+		Value: value,
+		Tags:  a.Tags,
+	}, nil
+}
+
+func (a serverAction) action() Action {
+	var value any
+	if len(a.Value) != 0 {
+		value = a.Value
+	}
+
+	return Action{
+		Name:  a.Name,
+		Value: value,
+		Tags:  a.Tags,
+	}
+}
+
+// serverActionResponse contains the actions posted and the states set by a
+// server action handler.
+type serverActionResponse struct {
+	Actions []serverAction             `json:",omitempty"`
+	States  map[string]json.RawMessage `json:",omitempty"`
+}
+
+// serverActionCompo is the component on behalf of which server action
+// handlers are executed.
+type serverActionCompo struct {
+	Compo
+}
+
+func (c *serverActionCompo) Render() UI {
+	return Div()
+}
+
+// handleServerAction executes the given handler with the given action and
+// returns the actions posted and the states set by the handler.
+func (e *engineX) handleServerAction(h ActionHandler, a Action) (serverActionResponse, error) {
+	source := &serverActionCompo{}
+	if err := e.Load(source); err != nil {
+		return serverActionResponse{}, errors.New("loading server action component failed").Wrap(err)
+	}
+
+	var mutex sync.Mutex
+	var res serverActionResponse
+
+	ctx := e.nodes.context(e.baseContext(), source)
+	ctx.postAction = func(ctx Context, a Action) {
+		action, err := makeServerAction(a)
+		if err != nil {
+			Log(errors.New("sending server action result failed").Wrap(err))
+			return
+		}
+
+		mutex.Lock()
+		res.Actions = append(res.Actions, action)
+		mutex.Unlock()
+	}
+
+	setState := ctx.setState
+	ctx.setState = func(ctx Context, state string, v any) State {
+		b, err := json.Marshal(v)
+		if err != nil {
+			Log(errors.New("sending server action state failed").
+				WithTag("state", state).
+				Wrap(err))
+		} else {
+			mutex.Lock()
+			if res.States == nil {
+				res.States = make(map[string]json.RawMessage)
+			}
+			res.States[state] = b
+			mutex.Unlock()
+		}
+		return setState(ctx, state, v)
+	}
+
+	ctx.Dispatch(func(ctx Context) {
+		h(ctx, a)
+	})
+	e.ConsumeAll()
+
+	return res, nil
+}
+
+// forwardServerAction sends the given action to the server, then sets the
+// states and posts the actions sent back by the server.
+func forwardServerAction(ctx Context, a Action) {
+	endpoint := serverActionsURL(Window().URL(), Getenv("GOAPP_ROOT_PREFIX"))
+	if err := postServerAction(ctx, endpoint, a); err != nil {
+		Log(errors.New("forwarding action to server failed").
+			WithTag("action", a.Name).
+			Wrap(err))
+	}
+}
+
+// serverActionsURL returns the URL of the server actions endpoint for the given
+// page URL, under the given root prefix.
+func serverActionsURL(page *url.URL, rootPrefix string) string {
+	endpoint := *page
+	endpoint.Path = serverActionsEndpoint(rootPrefix)
+	endpoint.RawQuery = ""
+	endpoint.Fragment = ""
+	return endpoint.String()
+}
+
+// serverActionsEndpoint returns the path of the server actions endpoint under
+// the given root prefix.
+func serverActionsEndpoint(rootPrefix string) string {
+	return strings.TrimRight(rootPrefix, "/") + serverActionsPath
+}
+
+func postServerAction(ctx Context, endpoint string, a Action) error {
+	action, err := makeServerAction(a)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(action)
+	if err != nil {
+		return errors.New("encoding action failed").Wrap(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.New("creating request failed").Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.New("sending request failed").Wrap(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("unexpected response status").
+			WithTag("status", res.StatusCode)
+	}
+
+	var response serverActionResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return errors.New("decoding response failed").Wrap(err)
+	}
+
+	for state, value := range response.States {
+		ctx.setState(ctx, state, transferredValue(value))
+	}
+	for _, a := range response.Actions {
+		ctx.postAction(ctx, a.action())
+	}
+	return nil
+}
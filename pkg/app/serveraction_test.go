@@ -0,0 +1,150 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleOnServer(t *testing.T) {
+	actionName := "/test/handleOnServer"
+	HandleOnServer(actionName, func(ctx Context, a Action) {})
+	defer delete(serverActionHandlers, actionName)
+
+	require.NotNil(t, serverActionHandlers[actionName])
+	require.NotContains(t, actionHandlers, actionName)
+}
+
+func TestHandlerServeServerAction(t *testing.T) {
+	actionName := "/test/serveServerAction"
+	HandleOnServer(actionName, func(ctx Context, a Action) {
+		var name string
+		err := json.Unmarshal(a.Value.(json.RawMessage), &name)
+		require.NoError(t, err)
+
+		ctx.SetState("/test/serveServerAction/greeting", "hello "+name)
+		ctx.NewActionWithValue("/test/serveServerAction/done", 42, T("source", "server"))
+	})
+	defer delete(serverActionHandlers, actionName)
+
+	h := Handler{Resources: LocalDir("")}
+
+	serve := func(method, contentType, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, serverActionsPath, strings.NewReader(body))
+		if contentType != "" {
+			r.Header.Set("Content-Type", contentType)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("action is handled", func(t *testing.T) {
+		w := serve(http.MethodPost, "application/json", `{"Name":"/test/serveServerAction","Value":"Maxence"}`)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+		var res serverActionResponse
+		err := json.NewDecoder(w.Body).Decode(&res)
+		require.NoError(t, err)
+		require.JSONEq(t, `"hello Maxence"`, string(res.States["/test/serveServerAction/greeting"]))
+		require.Len(t, res.Actions, 1)
+		require.Equal(t, "/test/serveServerAction/done", res.Actions[0].Name)
+		require.JSONEq(t, `42`, string(res.Actions[0].Value))
+		require.Equal(t, "server", res.Actions[0].Tags.Get("source"))
+	})
+
+	t.Run("non post request is rejected", func(t *testing.T) {
+		w := serve(http.MethodGet, "", "")
+		require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("non json request is rejected", func(t *testing.T) {
+		w := serve(http.MethodPost, "text/plain", `{"Name":"/test/serveServerAction"}`)
+		require.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("malformed request is rejected", func(t *testing.T) {
+		w := serve(http.MethodPost, "application/json", `{`)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unknown action is rejected", func(t *testing.T) {
+		w := serve(http.MethodPost, "application/json", `{"Name":"/test/serveServerAction/unknown"}`)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandlerServeServerActionWithRootPrefix(t *testing.T) {
+	actionName := "/test/serveServerActionWithRootPrefix"
+	HandleOnServer(actionName, func(ctx Context, a Action) {})
+	defer delete(serverActionHandlers, actionName)
+
+	h := Handler{Resources: GitHubPages("go-app")}
+	r := httptest.NewRequest(http.MethodPost, "/go-app"+serverActionsPath, strings.NewReader(`{"Name":"/test/serveServerActionWithRootPrefix"}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+}
+
+func TestServerActionsURL(t *testing.T) {
+	page, _ := url.Parse("https://murlok.io/go-app/hello?foo=bar#top")
+
+	require.Equal(t, "https://murlok.io/goapp/actions", serverActionsURL(page, "/"))
+	require.Equal(t, "https://murlok.io/goapp/actions", serverActionsURL(page, ""))
+	require.Equal(t, "https://murlok.io/go-app/goapp/actions", serverActionsURL(page, "/go-app"))
+}
+
+func TestPostServerAction(t *testing.T) {
+	actionName := "/test/postServerAction"
+	HandleOnServer(actionName, func(ctx Context, a Action) {
+		ctx.SetState("/test/postServerAction/user", serverActionUser{Name: "Max", Age: 42})
+		ctx.NewActionWithValue("/test/postServerAction/done", a.Value)
+	})
+	defer delete(serverActionHandlers, actionName)
+
+	server := httptest.NewServer(&Handler{Resources: LocalDir("")})
+	defer server.Close()
+
+	e := newTestEngine()
+	compo := &hello{}
+	e.Load(compo)
+	ctx := e.nodes.context(e.baseContext(), compo)
+
+	var done json.RawMessage
+	ctx.Handle("/test/postServerAction/done", func(ctx Context, a Action) {
+		done = a.Value.(json.RawMessage)
+	})
+
+	var user serverActionUser
+	ctx.ObserveState("/test/postServerAction/user", &user)
+
+	err := postServerAction(ctx, server.URL+serverActionsPath, Action{
+		Name:  actionName,
+		Value: "hi",
+	})
+	require.NoError(t, err)
+	e.ConsumeAll()
+
+	require.Equal(t, serverActionUser{Name: "Max", Age: 42}, user)
+	require.JSONEq(t, `"hi"`, string(done))
+
+	var stored serverActionUser
+	ctx.GetState("/test/postServerAction/user", &stored)
+	require.Equal(t, user, stored)
+
+	err = postServerAction(ctx, server.URL+serverActionsPath, Action{Name: "/test/postServerAction/unknown"})
+	require.Error(t, err)
+}
+
+type serverActionUser struct {
+	Name string
+	Age  int
+}
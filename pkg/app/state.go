@@ -3,6 +3,7 @@ package app
 import (
 	"encoding/json"
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 
@@ -13,40 +14,50 @@ import (
 // State represents a state with additional features such as expiration,
 // persistence, and broadcasting capabilities.
 type State struct {
-	value     any
-	expiresAt time.Time
+	value       any
+	expiresAt   time.Time
+	persistedAt time.Time
+	encrypted   bool
+	transferred bool
 
 	ctx       Context
 	name      string
 	expire    func(State, time.Time) State
 	persist   func(State, bool) State
 	broadcast func(State) State
+	transfer  func(State) State
+	history   func(State, int) State
 }
 
 // ExpiresIn sets the expiration time for the state by specifying a duration
-// from the current time.
+// from the current time. In the browser, the state is removed when it expires
+// and its observers are notified with the zero value.
 func (s State) ExpiresIn(v time.Duration) State {
 	return s.expire(s, time.Now().Add(v))
 }
 
-// ExpiresAt sets the exact expiration time for the state.
+// ExpiresAt sets the exact expiration time for the state. In the browser, the
+// state is removed when it expires and its observers are notified with the
+// zero value.
 func (s State) ExpiresAt(v time.Time) State {
 	return s.expire(s, v)
 }
 
-// Persist ensures the state is persisted into the local storage.
+// Persist ensures the state is persisted into the local storage, or into the
+// storage set with SetPersistedStateStorage.
 func (s State) Persist() State {
 	return s.persist(s, false)
 }
 
-// PersistWithEncryption ensures the state is persisted into the local storage
-// with encryption.
+// PersistWithEncryption ensures the state is persisted with encryption into the
+// local storage, or into the storage set with SetPersistedStateStorage.
 func (s State) PersistWithEncryption() State {
 	return s.persist(s, true)
 }
 
 // Broadcast signals that changes to the state will be broadcasted to other
-// browser tabs and windows sharing the same origin when it is supported.
+// browser tabs and windows sharing the same origin. Changes are sent through
+// local storage events when BroadcastChannel is not supported.
 //
 // Using Broadcast creates a BroadcastChannel, which prevents the page from
 // being cached. This may impact the Chrome Lighthouse performance score due to
@@ -55,10 +66,173 @@ func (s State) Broadcast() State {
 	return s.broadcast(s)
 }
 
+// Transfer ensures the state value set during server-side pre-rendering is sent
+// to the browser along with the page, where it is restored before the page is
+// first displayed. The value is encoded in JSON and must be retrieved into a
+// receiver of a type that it can be decoded into. It has no effect in the
+// browser.
+func (s State) Transfer() State {
+	return s.transfer(s)
+}
+
+// WithHistory records the previous values of the state, up to the given
+// depth, which can be restored with Context.UndoState and Context.RedoState.
+// A depth of 0 or less disables the history.
+func (s State) WithHistory(depth int) State {
+	return s.history(s, depth)
+}
+
 type storableState struct {
 	Value          json.RawMessage `json:",omitempty"`
 	EncryptedValue []byte          `json:",omitempty"`
 	ExpiresAt      time.Time       `json:",omitempty"`
+	Version        int             `json:",omitempty"`
+	UpdatedAt      time.Time       `json:",omitempty"`
+}
+
+// SetPersistedStateStorage sets the storage where persisted states are stored.
+// States are persisted in the local storage by default.
+//
+// The storage is only used in the web browser. When pre-rendering on the
+// server, persisted states are kept in the local storage of each page
+// rendering, which is never shared between requests.
+//
+// Example:
+//
+//	app.SetPersistedStateStorage(app.NewIndexedDBStorage("states"))
+func SetPersistedStateStorage(s BrowserStorage) {
+	persistedStateStorage = s
+}
+
+var persistedStateStorage BrowserStorage
+
+// RegisterStateSchema associates a persisted state with a schema that versions
+// its values. Values persisted with a previous version are migrated when they
+// are retrieved from the local storage, then persisted again with the current
+// version.
+//
+// Example:
+//
+//	app.RegisterStateSchema("/user", app.StateSchema{
+//	    Version: 1,
+//	    Migrations: map[int]func(json.RawMessage) (json.RawMessage, error){
+//	        0: migrateUserV0ToV1,
+//	    },
+//	    InvalidValuePolicy: app.DropInvalidStateValue,
+//	})
+func RegisterStateSchema(state string, s StateSchema) {
+	stateSchemas[state] = s
+}
+
+var stateSchemas = make(map[string]StateSchema)
+
+// StateSchema describes the version of a persisted state value and how to
+// handle the values persisted with other versions.
+type StateSchema struct {
+	// The version of the values that are persisted. Values persisted before a
+	// schema was registered have the version 0.
+	Version int
+
+	// Migrations maps versions to the function that converts the JSON encoded
+	// values of that version to the next version.
+	Migrations map[int]func(json.RawMessage) (json.RawMessage, error)
+
+	// InvalidValuePolicy defines what happens to persisted values that cannot
+	// be decrypted, migrated or decoded.
+	InvalidValuePolicy InvalidStateValuePolicy
+
+	// OnInvalidValue is called with the state name, the JSON encoded value and
+	// the error when a persisted value cannot be decrypted, migrated or
+	// decoded. The value is nil when it cannot be decrypted. Errors are logged
+	// when not set.
+	OnInvalidValue func(ctx Context, state string, value json.RawMessage, err error)
+}
+
+func (s StateSchema) migrate(v json.RawMessage, version int) (json.RawMessage, error) {
+	if version > s.Version {
+		return nil, errors.New("persisted value version is greater than schema version").
+			WithTag("version", version).
+			WithTag("schema-version", s.Version)
+	}
+
+	for ; version < s.Version; version++ {
+		migrate, ok := s.Migrations[version]
+		if !ok {
+			return nil, errors.New("persisted value migration is missing").
+				WithTag("version", version)
+		}
+
+		var err error
+		if v, err = migrate(v); err != nil {
+			return nil, errors.New("migrating persisted value failed").
+				WithTag("version", version).
+				Wrap(err)
+		}
+	}
+	return v, nil
+}
+
+func (s StateSchema) handleInvalidValue(ctx Context, state string, v json.RawMessage, err error) error {
+	if s.InvalidValuePolicy == DropInvalidStateValue {
+		ctx.persistedStateStorage().Del(state)
+	}
+
+	if s.OnInvalidValue != nil {
+		s.OnInvalidValue(ctx, state, v, err)
+		return nil
+	}
+	return err
+}
+
+// InvalidStateValuePolicy defines what happens to persisted state values that
+// cannot be decrypted, migrated or decoded.
+type InvalidStateValuePolicy int
+
+const (
+	// KeepInvalidStateValue leaves invalid values in the local storage.
+	KeepInvalidStateValue InvalidStateValuePolicy = iota
+
+	// DropInvalidStateValue removes invalid values from the local storage.
+	DropInvalidStateValue
+)
+
+// StateTransaction stages state changes that are applied at once when the
+// transaction is committed.
+type StateTransaction struct {
+	ctx     Context
+	get     func(Context, string, any)
+	changes map[string]stateChange
+}
+
+type stateChange struct {
+	value   any
+	deleted bool
+}
+
+// Get fetches the value of a state, including the changes staged in the
+// transaction.
+func (tx *StateTransaction) Get(state string, recv any) {
+	change, staged := tx.changes[state]
+	if !staged {
+		tx.get(tx.ctx, state, recv)
+		return
+	}
+
+	if err := storeValue(recv, change.value); err != nil {
+		Log(errors.New("getting transaction state failed").
+			WithTag("state", state).
+			Wrap(err))
+	}
+}
+
+// Set stages the modification of a state with the provided value.
+func (tx *StateTransaction) Set(state string, v any) {
+	tx.changes[state] = stateChange{value: v}
+}
+
+// Delete stages the removal of a state.
+func (tx *StateTransaction) Delete(state string) {
+	tx.changes[state] = stateChange{deleted: true}
 }
 
 // Observer represents a mechanism to monitor and react to changes in a state.
@@ -67,11 +241,14 @@ type Observer struct {
 	receiver      any
 	condition     func() bool
 	changeHandler func()
+	expireHandler func()
 	broadcast     bool
+	storageSync   bool
 
-	state           string
-	setObserver     func(Observer) Observer
-	enableBroadcast func()
+	state             string
+	setObserver       func(Observer) Observer
+	enableBroadcast   func()
+	enableStorageSync func()
 }
 
 // While sets a condition for the observer, determining whether it observes
@@ -89,6 +266,14 @@ func (o Observer) OnChange(h func()) Observer {
 	return o.setObserver(o)
 }
 
+// OnExpire sets a callback function to be executed when the associated state
+// expires. The receiver is set to its zero value and the OnChange callback is
+// executed before.
+func (o Observer) OnExpire(h func()) Observer {
+	o.expireHandler = h
+	return o.setObserver(o)
+}
+
 // WithBroadcast enables the observer to listen to state changes that are
 // broadcasted by other browser tabs or windows. This is useful for s
 // ynchronizing state across multiple open instances of a web application within
@@ -104,6 +289,19 @@ func (o Observer) WithBroadcast() Observer {
 	return o.setObserver(o)
 }
 
+// WithStorageSync enables the observer to listen to changes made by other
+// browser tabs or windows to the persisted value of the state. Conflicting
+// changes are resolved by keeping the most recently persisted value.
+//
+// Unlike WithBroadcast, it relies on storage events, which do not prevent the
+// page from being cached. It has no effect when states are persisted into a
+// storage set with SetPersistedStateStorage.
+func (o Observer) WithStorageSync() Observer {
+	o.enableStorageSync()
+	o.storageSync = true
+	return o.setObserver(o)
+}
+
 func (o Observer) observing() bool {
 	if o.source == nil || !o.source.Mounted() {
 		return false
@@ -118,12 +316,23 @@ func (o Observer) observing() bool {
 // to state values. It supports concurrency-safe operations and provides
 // functionality to observe state changes.
 type stateManager struct {
-	mutex             sync.RWMutex
-	states            map[string]State
-	observers         map[string]map[UI]Observer
-	initBroadcastOnce sync.Once
-	broadcastStoreID  string
-	broadcastChannel  Value
+	mutex               sync.RWMutex
+	states              map[string]State
+	computed            map[string]*computedState
+	histories           map[string]*stateHistory
+	expirations         map[string]*time.Timer
+	timedExpirations    bool
+	historyGroup        map[string]struct{}
+	historyGroupDepth   int
+	historyGroupID      uint64
+	historyGroupCount   uint64
+	computing           map[string]bool
+	dependents          map[string]map[string]struct{}
+	observers           map[string]map[UI]Observer
+	initBroadcastOnce   sync.Once
+	broadcastStoreID    string
+	broadcastChannel    Value
+	initStorageSyncOnce sync.Once
 }
 
 // Observe initiates observation for a specified state, ensuring the state
@@ -133,11 +342,12 @@ func (m *stateManager) Observe(ctx Context, state string, receiver any) Observer
 	m.Get(ctx, state, receiver)
 
 	return m.setObserver(Observer{
-		source:          ctx.Src(),
-		receiver:        receiver,
-		state:           state,
-		setObserver:     m.setObserver,
-		enableBroadcast: func() { m.initBroadcast(ctx) },
+		source:            ctx.Src(),
+		receiver:          receiver,
+		state:             state,
+		setObserver:       m.setObserver,
+		enableBroadcast:   func() { m.initBroadcast(ctx) },
+		enableStorageSync: func() { m.initStorageSync(ctx) },
 	})
 }
 
@@ -159,7 +369,9 @@ func (m *stateManager) setObserver(v Observer) Observer {
 		receiver:      v.receiver,
 		condition:     v.condition,
 		changeHandler: v.changeHandler,
+		expireHandler: v.expireHandler,
 		broadcast:     v.broadcast,
+		storageSync:   v.storageSync,
 	}
 
 	return v
@@ -168,11 +380,14 @@ func (m *stateManager) setObserver(v Observer) Observer {
 // Get retrieves the value of a specific state, setting it to the provided
 // receiver.
 func (m *stateManager) Get(ctx Context, state string, receiver any) {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
+	if m.getComputed(ctx, state, receiver) {
+		return
+	}
 
+	m.mutex.Lock()
 	value, exists := m.states[state]
 	if !exists {
+		m.mutex.Unlock()
 		if err := m.getStoredState(ctx, state, receiver); err != nil {
 			Log(errors.New("getting state from local storage failed").
 				WithTag("state", state).
@@ -180,10 +395,20 @@ func (m *stateManager) Get(ctx Context, state string, receiver any) {
 		}
 		return
 	}
+	defer m.mutex.Unlock()
 
 	if expiredTime(value.expiresAt) {
 		delete(m.states, state)
-		ctx.LocalStorage().Del(state)
+		ctx.persistedStateStorage().Del(state)
+		return
+	}
+
+	if transferred, ok := value.value.(transferredValue); ok {
+		if err := json.Unmarshal(transferred, receiver); err != nil {
+			Log(errors.New("getting transferred state failed").
+				WithTag("state", state).
+				Wrap(err))
+		}
 		return
 	}
 
@@ -196,30 +421,90 @@ func (m *stateManager) Get(ctx Context, state string, receiver any) {
 
 func (m *stateManager) getStoredState(ctx Context, state string, receiver any) error {
 	var value storableState
-	if err := ctx.LocalStorage().Get(state, &value); err != nil {
+	if err := ctx.persistedStateStorage().Get(state, &value); err != nil {
 		return err
 	}
 
 	if expiredTime(value.ExpiresAt) {
-		ctx.LocalStorage().Del(state)
+		ctx.persistedStateStorage().Del(state)
+		return nil
+	}
+
+	schema := stateSchemas[state]
+	encrypted := len(value.EncryptedValue) != 0
+
+	raw := value.Value
+	if encrypted {
+		b, err := decrypt(ctx.cryptoKey(), value.EncryptedValue)
+		if err != nil {
+			return schema.handleInvalidValue(ctx, state, nil,
+				errors.New("decrypting value failed").Wrap(err))
+		}
+		raw = b
+	}
+	if len(raw) == 0 {
 		return nil
 	}
 
-	if len(value.EncryptedValue) != 0 {
-		return ctx.Decrypt(value.EncryptedValue, receiver)
-	} else if len(value.Value) != 0 {
-		return json.Unmarshal(value.Value, receiver)
+	migrated := value.Version != schema.Version
+	if migrated {
+		b, err := schema.migrate(raw, value.Version)
+		if err != nil {
+			return schema.handleInvalidValue(ctx, state, raw, err)
+		}
+		raw = b
 	}
-	return nil
+
+	if err := json.Unmarshal(raw, receiver); err != nil {
+		return schema.handleInvalidValue(ctx, state, raw,
+			errors.New("decoding value failed").Wrap(err))
+	}
+
+	if !migrated {
+		return nil
+	}
+	value.Version = schema.Version
+	if encrypted {
+		b, err := encrypt(ctx.cryptoKey(), raw)
+		if err != nil {
+			return errors.New("encrypting migrated value failed").Wrap(err)
+		}
+		value.EncryptedValue = b
+	} else {
+		value.Value = raw
+	}
+	return ctx.persistedStateStorage().Set(state, value)
 }
 
 func (m *stateManager) Set(ctx Context, state string, v any) State {
+	m.mutex.Lock()
+	m.recordHistory(state)
+	m.mutex.Unlock()
+
+	m.set(ctx, state, v)
+	m.invalidate(ctx, false, state)
+
+	return State{
+		value:     v,
+		ctx:       ctx,
+		name:      state,
+		expire:    m.setExpiration,
+		persist:   m.persist,
+		broadcast: m.broadcast,
+		transfer:  m.transfer,
+		history:   m.setHistory,
+	}
+}
+
+func (m *stateManager) set(ctx Context, state string, v any) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
 	if m.states == nil {
 		m.states = make(map[string]State)
 	}
+	m.removeComputed(state)
+	m.cancelExpiration(state)
 
 	value := State{value: v}
 	m.states[state] = value
@@ -258,15 +543,6 @@ func (m *stateManager) Set(ctx Context, state string, v any) State {
 			}
 		})
 	}
-
-	return State{
-		value:     v,
-		ctx:       ctx,
-		name:      state,
-		expire:    m.setExpiration,
-		persist:   m.persist,
-		broadcast: m.broadcast,
-	}
 }
 
 // Set updates a specified state with a new value and notifies its observers.
@@ -281,54 +557,220 @@ func (m *stateManager) setExpiration(s State, v time.Time) State {
 	value.expiresAt = v
 	m.states[s.name] = value
 
+	m.scheduleExpiration(s.ctx, s.name, v)
 	return s
 }
 
+// scheduleExpiration schedules the expiration of a state at the given time
+// when timed expirations are enabled. It cancels any previously scheduled
+// expiration.
+func (m *stateManager) scheduleExpiration(ctx Context, state string, expiresAt time.Time) {
+	m.cancelExpiration(state)
+	if !m.timedExpirations || expiresAt.IsZero() {
+		return
+	}
+
+	if m.expirations == nil {
+		m.expirations = make(map[string]*time.Timer)
+	}
+	m.expirations[state] = time.AfterFunc(time.Until(expiresAt), func() {
+		m.expire(ctx, state, expiresAt)
+	})
+}
+
+func (m *stateManager) cancelExpiration(state string) {
+	if timer := m.expirations[state]; timer != nil {
+		timer.Stop()
+		delete(m.expirations, state)
+	}
+}
+
+// expire removes a state that expired at the given time, then sets the
+// receivers of its observers to their zero value and notifies them. The state
+// is left untouched when it has been set with another expiration time.
+func (m *stateManager) expire(ctx Context, state string, expiresAt time.Time) {
+	m.mutex.Lock()
+	if value, exists := m.states[state]; exists {
+		if !value.expiresAt.Equal(expiresAt) {
+			m.mutex.Unlock()
+			return
+		}
+		delete(m.states, state)
+	} else {
+		var stored storableState
+		ctx.persistedStateStorage().Get(state, &stored)
+		if !stored.ExpiresAt.Equal(expiresAt) {
+			m.mutex.Unlock()
+			return
+		}
+	}
+	delete(m.expirations, state)
+	ctx.persistedStateStorage().Del(state)
+
+	observers := make([]Observer, 0, len(m.observers[state]))
+	for _, o := range m.observers[state] {
+		observers = append(observers, o)
+	}
+	m.mutex.Unlock()
+
+	for _, observer := range observers {
+		o := observer
+		ctx.sourceElement = o.source
+
+		ctx.Dispatch(func(ctx Context) {
+			if !o.observing() {
+				m.mutex.Lock()
+				delete(m.observers[state], o.source)
+				m.mutex.Unlock()
+				return
+			}
+
+			if err := storeValue(o.receiver, nil); err != nil {
+				Log(errors.New("storing expired state value into receiver failed").
+					WithTag("state", state).
+					WithTag("observer-type", reflect.TypeOf(o.source)).
+					WithTag("receiver-type", reflect.TypeOf(o.receiver)).
+					Wrap(err))
+				return
+			}
+
+			if o.changeHandler != nil {
+				o.changeHandler()
+			}
+			if o.expireHandler != nil {
+				o.expireHandler()
+			}
+		})
+	}
+
+	m.invalidate(ctx, false, state)
+}
+
 func (m *stateManager) persist(s State, encrypt bool) State {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	value := storableState{ExpiresAt: s.expiresAt}
+	updatedAt := time.Now()
+	if err := m.store(s.ctx, s.name, s.value, s.expiresAt, encrypt, updatedAt); err != nil {
+		Log(errors.New("persisting state failed").
+			WithTag("state", s.name).
+			WithTag("encrypted", encrypt).
+			Wrap(err))
+		return s
+	}
+
+	s.persistedAt = updatedAt
+	s.encrypted = encrypt
+	if current, exists := m.states[s.name]; exists {
+		current.persistedAt = updatedAt
+		current.encrypted = encrypt
+		m.states[s.name] = current
+	}
+	return s
+}
+
+// store writes the given state value into the persisted state storage.
+func (m *stateManager) store(ctx Context, state string, v any, expiresAt time.Time, encrypt bool, updatedAt time.Time) error {
+	value := storableState{
+		ExpiresAt: expiresAt,
+		Version:   stateSchemas[state].Version,
+		UpdatedAt: updatedAt,
+	}
 	if encrypt {
-		b, err := s.ctx.Encrypt(s.value)
+		b, err := ctx.Encrypt(v)
 		if err != nil {
-			Log(errors.New("persisting encrypted state failed").
-				WithTag("state", s.name).
-				Wrap(err))
-			return s
+			return err
 		}
 		value.EncryptedValue = b
 	} else {
-		b, err := json.Marshal(s.value)
+		b, err := json.Marshal(v)
 		if err != nil {
-			Log(errors.New("persisting state failed").
-				WithTag("state", s.name).
-				Wrap(err))
-			return s
+			return errors.New("encoding value failed").Wrap(err)
 		}
 		value.Value = b
 	}
+	return ctx.persistedStateStorage().Set(state, value)
+}
 
-	if err := s.ctx.LocalStorage().Set(s.name, value); err != nil {
-		Log(errors.New("persisting state failed").
-			WithTag("state", s.name).
-			Wrap(err))
+func (m *stateManager) transfer(s State) State {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if IsClient {
+		return s
 	}
+
+	s.transferred = true
+
+	value := m.states[s.name]
+	value.transferred = true
+	m.states[s.name] = value
+
 	return s
 }
 
-func (m *stateManager) broadcast(s State) State {
+// TransferredStates encodes the states marked to be transferred to the
+// browser. It returns nil when there are no such states.
+func (m *stateManager) TransferredStates() ([]byte, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var states map[string]storableState
+	for name, state := range m.states {
+		if !state.transferred || expiredTime(state.expiresAt) {
+			continue
+		}
+
+		value, err := json.Marshal(state.value)
+		if err != nil {
+			return nil, errors.New("encoding transferred state failed").
+				WithTag("state", name).
+				Wrap(err)
+		}
+
+		if states == nil {
+			states = make(map[string]storableState)
+		}
+		states[name] = storableState{
+			Value:     value,
+			ExpiresAt: state.expiresAt,
+		}
+	}
+
+	if states == nil {
+		return nil, nil
+	}
+	return json.Marshal(states)
+}
+
+// RestoreTransferredStates restores the states encoded by TransferredStates.
+// Restored values are decoded into the receivers given when the states are
+// retrieved.
+func (m *stateManager) RestoreTransferredStates(v []byte) error {
+	var states map[string]storableState
+	if err := json.Unmarshal(v, &states); err != nil {
+		return errors.New("decoding transferred states failed").Wrap(err)
+	}
+
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	m.initBroadcast(s.ctx)
-
-	if m.broadcastChannel == nil {
-		Log(errors.New("broadcast not supported").
-			WithTag("state", s.name))
-		return s
+	if m.states == nil {
+		m.states = make(map[string]State)
+	}
+	for name, state := range states {
+		if expiredTime(state.ExpiresAt) {
+			continue
+		}
+		m.states[name] = State{
+			value:     transferredValue(state.Value),
+			expiresAt: state.ExpiresAt,
+		}
 	}
+	return nil
+}
 
+func (m *stateManager) broadcast(s State) State {
 	b, err := json.Marshal(s.value)
 	if err != nil {
 		Log(errors.New("encoding broadcast state failed").
@@ -337,42 +779,129 @@ func (m *stateManager) broadcast(s State) State {
 		return s
 	}
 
-	m.broadcastChannel.Call("postMessage", map[string]any{
-		"StoreID": m.broadcastStoreID,
-		"State":   s.name,
-		"Value":   string(b),
+	m.postBroadcast(s.ctx, stateBroadcast{
+		State: s.name,
+		Value: string(b),
 	})
 	return s
 }
 
+// BroadcastAction sends the given action to the other tabs.
+func (m *stateManager) BroadcastAction(ctx Context, a Action) {
+	var value string
+	if a.Value != nil {
+		b, err := json.Marshal(a.Value)
+		if err != nil {
+			Log(errors.New("encoding broadcast action failed").
+				WithTag("action", a.Name).
+				Wrap(err))
+			return
+		}
+		value = string(b)
+	}
+
+	m.postBroadcast(ctx, stateBroadcast{
+		Action: a.Name,
+		Value:  value,
+		Tags:   a.Tags,
+	})
+}
+
+func (m *stateManager) postBroadcast(ctx Context, msg stateBroadcast) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.initBroadcast(ctx)
+	msg.StoreID = m.broadcastStoreID
+
+	if m.broadcastChannel != nil {
+		data := map[string]any{
+			"StoreID": msg.StoreID,
+			"State":   msg.State,
+			"Value":   msg.Value,
+		}
+		if msg.Action != "" {
+			tags, _ := json.Marshal(msg.Tags)
+			data["Action"] = msg.Action
+			data["Tags"] = string(tags)
+		}
+		m.broadcastChannel.Call("postMessage", data)
+		return
+	}
+
+	// Without BroadcastChannel, the message is set then immediately removed
+	// from the local storage, which emits a storage event in the other tabs.
+	b, err := json.Marshal(msg)
+	if err != nil {
+		Log(errors.New("encoding broadcast message failed").
+			WithTag("state", msg.State).
+			WithTag("action", msg.Action).
+			Wrap(err))
+		return
+	}
+	localStorage := Window().Get("localStorage")
+	localStorage.Call("setItem", stateBroadcastKey, string(b))
+	localStorage.Call("removeItem", stateBroadcastKey)
+}
+
 func (m *stateManager) initBroadcast(ctx Context) {
 	m.initBroadcastOnce.Do(func() {
+		m.broadcastStoreID = uuid.NewString()
+
 		broadcastChannel := Window().Get("BroadcastChannel")
 		if !broadcastChannel.Truthy() {
+			m.initStorageSync(ctx)
 			return
 		}
 		broadcastChannel = broadcastChannel.New("go-app-broadcast-states")
 		m.broadcastChannel = broadcastChannel
-		m.broadcastStoreID = uuid.NewString()
 
 		handleBroadcast := FuncOf(func(this Value, args []Value) any {
-			m.handleBroadcast(ctx, args[0].Get("data"))
+			data := args[0].Get("data")
+			msg := stateBroadcast{
+				StoreID: data.Get("StoreID").String(),
+				State:   data.Get("State").String(),
+				Value:   data.Get("Value").String(),
+			}
+			if action := data.Get("Action"); action.Truthy() {
+				msg.Action = action.String()
+				if err := json.Unmarshal([]byte(data.Get("Tags").String()), &msg.Tags); err != nil {
+					Log(errors.New("decoding broadcast action tags failed").
+						WithTag("action", msg.Action).
+						Wrap(err))
+				}
+			}
+			m.handleBroadcast(ctx, msg)
 			return nil
 		})
 		broadcastChannel.Set("onmessage", handleBroadcast)
 	})
 }
 
-func (m *stateManager) handleBroadcast(ctx Context, data Value) {
-	if storeID := data.Get("StoreID").String(); storeID == "" || storeID == m.broadcastStoreID {
+func (m *stateManager) handleBroadcast(ctx Context, msg stateBroadcast) {
+	if msg.StoreID == "" || msg.StoreID == m.broadcastStoreID {
+		return
+	}
+
+	if msg.Action != "" {
+		var value any
+		if msg.Value != "" {
+			value = json.RawMessage(msg.Value)
+		}
+		ctx.postAction(ctx, Action{
+			Name:        msg.Action,
+			Value:       value,
+			Tags:        msg.Tags,
+			broadcasted: true,
+		})
 		return
 	}
 
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	state := data.Get("State").String()
-	value := []byte(data.Get("Value").String())
+	state := msg.State
+	value := []byte(msg.Value)
 
 	for _, observer := range m.observers[state] {
 		o := observer
@@ -404,14 +933,508 @@ func (m *stateManager) handleBroadcast(ctx Context, data Value) {
 	}
 }
 
+// initStorageSync listens to the storage events emitted when the local storage
+// is modified by other tabs.
+func (m *stateManager) initStorageSync(ctx Context) {
+	m.initStorageSyncOnce.Do(func() {
+		localStorage := Window().Get("localStorage")
+
+		Window().addEventListener("storage", FuncOf(func(this Value, args []Value) any {
+			event := args[0]
+			key := event.Get("key")
+			if !key.Truthy() || !event.Get("storageArea").Equal(localStorage) {
+				return nil
+			}
+
+			var newValue string
+			if v := event.Get("newValue"); v.Truthy() {
+				newValue = v.String()
+			}
+			m.handleStorageChange(ctx, key.String(), newValue)
+			return nil
+		}), nil)
+	})
+}
+
+// handleStorageChange handles a local storage item modified by another tab. The
+// new value is empty when the item is removed.
+func (m *stateManager) handleStorageChange(ctx Context, key, newValue string) {
+	if key == stateBroadcastKey {
+		if newValue == "" {
+			return
+		}
+
+		var msg stateBroadcast
+		if err := json.Unmarshal([]byte(newValue), &msg); err != nil {
+			Log(errors.New("decoding broadcast message failed").Wrap(err))
+			return
+		}
+		m.handleBroadcast(ctx, msg)
+		return
+	}
+
+	if persistedStateStorage != nil && IsClient {
+		return
+	}
+	m.syncPersistedState(ctx, key, newValue)
+}
+
+// syncPersistedState synchronizes a state with its persisted value modified by
+// another tab. The in-memory value is discarded in favor of the persisted one,
+// unless it was persisted more recently, in which case it is persisted again
+// to overwrite the older value.
+func (m *stateManager) syncPersistedState(ctx Context, state, newValue string) {
+	var value storableState
+	if newValue != "" {
+		if err := json.Unmarshal([]byte(newValue), &value); err != nil {
+			return
+		}
+		if len(value.Value) == 0 && len(value.EncryptedValue) == 0 {
+			return
+		}
+	}
+
+	m.mutex.Lock()
+	if _, computed := m.computed[state]; computed {
+		m.mutex.Unlock()
+		return
+	}
+
+	current, exists := m.states[state]
+	if exists && newValue != "" && value.UpdatedAt.Before(current.persistedAt) {
+		err := m.store(ctx, state, current.value, current.expiresAt, current.encrypted, current.persistedAt)
+		m.mutex.Unlock()
+		if err != nil {
+			Log(errors.New("restoring persisted state failed").
+				WithTag("state", state).
+				Wrap(err))
+		}
+		return
+	}
+	delete(m.states, state)
+
+	var observers []Observer
+	for _, o := range m.observers[state] {
+		if o.storageSync {
+			observers = append(observers, o)
+		}
+	}
+	m.mutex.Unlock()
+
+	for _, observer := range observers {
+		o := observer
+		ctx.sourceElement = o.source
+
+		ctx.Dispatch(func(ctx Context) {
+			if !o.observing() {
+				m.mutex.Lock()
+				delete(m.observers[state], o.source)
+				m.mutex.Unlock()
+				return
+			}
+
+			if newValue == "" {
+				storeValue(o.receiver, nil)
+			} else {
+				m.Get(ctx, state, o.receiver)
+			}
+			if o.changeHandler != nil {
+				o.changeHandler()
+			}
+		})
+	}
+
+	m.invalidate(ctx, false, state)
+}
+
 // Delete removes the specified state from the managed states and also deletes
 // it from the local storage if it was previously persisted.
 func (m *stateManager) Delete(ctx Context, state string) {
+	m.mutex.Lock()
+	delete(m.states, state)
+	delete(m.histories, state)
+	m.removeComputed(state)
+	m.cancelExpiration(state)
+	ctx.persistedStateStorage().Del(state)
+	m.mutex.Unlock()
+
+	m.invalidate(ctx, false, state)
+}
+
+// Transaction calls the given function with a transaction that stages state
+// changes. The changes are applied at once when the function returns without
+// error, then each affected observer is notified once. They are discarded
+// otherwise, and the error is returned.
+func (m *stateManager) Transaction(ctx Context, f func(*StateTransaction) error) error {
+	tx := StateTransaction{
+		ctx:     ctx,
+		get:     m.Get,
+		changes: make(map[string]stateChange),
+	}
+	if err := f(&tx); err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	if m.states == nil {
+		m.states = make(map[string]State)
+	}
+
+	var set, deleted []string
+	for state, change := range tx.changes {
+		if change.deleted {
+			delete(m.states, state)
+			delete(m.histories, state)
+			m.removeComputed(state)
+			ctx.persistedStateStorage().Del(state)
+			deleted = append(deleted, state)
+			continue
+		}
+
+		m.recordHistory(state)
+		m.removeComputed(state)
+		m.states[state] = State{value: change.value}
+		set = append(set, state)
+	}
+	m.mutex.Unlock()
+
+	m.invalidate(ctx, true, set...)
+	m.invalidate(ctx, false, deleted...)
+	return nil
+}
+
+func (m *stateManager) setHistory(s State, depth int) State {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
+	if depth <= 0 {
+		delete(m.histories, s.name)
+		return s
+	}
+
+	if m.histories == nil {
+		m.histories = make(map[string]*stateHistory)
+	}
+	h := m.histories[s.name]
+	if h == nil {
+		h = &stateHistory{}
+		m.histories[s.name] = h
+	}
+	h.depth = depth
+	h.trim()
+
+	return s
+}
+
+func (m *stateManager) recordHistory(state string) {
+	h := m.histories[state]
+	if h == nil {
+		return
+	}
+
+	if m.historyGroupDepth > 0 {
+		if _, recorded := m.historyGroup[state]; recorded {
+			return
+		}
+		m.historyGroup[state] = struct{}{}
+	}
+
+	h.undos = append(h.undos, historyStep{
+		value: m.states[state].value,
+		group: m.historyGroupID,
+	})
+	h.redos = nil
+	h.trim()
+}
+
+// GroupHistory records the changes made to states with history during the
+// execution of the given function as a single history step shared by all the
+// changed states. Undoing or redoing one of them restores all of them.
+func (m *stateManager) GroupHistory(f func()) {
+	m.mutex.Lock()
+	if m.historyGroupDepth == 0 {
+		m.historyGroup = make(map[string]struct{})
+		m.historyGroupCount++
+		m.historyGroupID = m.historyGroupCount
+	}
+	m.historyGroupDepth++
+	m.mutex.Unlock()
+
+	defer func() {
+		m.mutex.Lock()
+		m.historyGroupDepth--
+		if m.historyGroupDepth == 0 {
+			m.historyGroup = nil
+			m.historyGroupID = 0
+		}
+		m.mutex.Unlock()
+	}()
+
+	f()
+}
+
+// Undo restores the previous value of a state with history and notifies its
+// observers. States changed in the same GroupHistory call are restored
+// together.
+func (m *stateManager) Undo(ctx Context, state string) {
+	m.restoreHistory(ctx, state, false)
+}
+
+// Redo restores the value of a state with history that was replaced by Undo
+// and notifies its observers. States changed in the same GroupHistory call are
+// restored together.
+func (m *stateManager) Redo(ctx Context, state string) {
+	m.restoreHistory(ctx, state, true)
+}
+
+func (m *stateManager) restoreHistory(ctx Context, state string, redo bool) {
+	m.mutex.Lock()
+	h := m.histories[state]
+	if h == nil {
+		m.mutex.Unlock()
+		return
+	}
+
+	steps := h.undos
+	if redo {
+		steps = h.redos
+	}
+	if len(steps) == 0 {
+		m.mutex.Unlock()
+		return
+	}
+
+	states := []string{state}
+	if group := steps[len(steps)-1].group; group != 0 {
+		states = states[:0]
+		for name, h := range m.histories {
+			steps := h.undos
+			if redo {
+				steps = h.redos
+			}
+			if len(steps) != 0 && steps[len(steps)-1].group == group {
+				states = append(states, name)
+			}
+		}
+		sort.Strings(states)
+	}
+
+	values := make([]any, len(states))
+	for i, name := range states {
+		h := m.histories[name]
+		from, to := &h.undos, &h.redos
+		if redo {
+			from, to = to, from
+		}
+
+		step := (*from)[len(*from)-1]
+		*from = (*from)[:len(*from)-1]
+		*to = append(*to, historyStep{
+			value: m.states[name].value,
+			group: step.group,
+		})
+		values[i] = step.value
+	}
+	m.mutex.Unlock()
+
+	for i, name := range states {
+		m.set(ctx, name, values[i])
+	}
+	m.invalidate(ctx, false, states...)
+}
+
+// CanUndo reports whether a state with history has a previous value to
+// restore.
+func (m *stateManager) CanUndo(state string) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	h := m.histories[state]
+	return h != nil && len(h.undos) != 0
+}
+
+// CanRedo reports whether a state with history has a value replaced by Undo to
+// restore.
+func (m *stateManager) CanRedo(state string) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	h := m.histories[state]
+	return h != nil && len(h.redos) != 0
+}
+
+// Compute defines a state whose value is computed with the given function. The
+// states retrieved with the context given to the function are tracked as its
+// dependencies. The value is computed again when the state is retrieved after
+// a dependency changed, and observers are notified of dependency changes.
+func (m *stateManager) Compute(ctx Context, state string, compute func(Context) any) {
+	m.mutex.Lock()
+	if m.computed == nil {
+		m.computed = make(map[string]*computedState)
+	}
+	m.removeComputed(state)
 	delete(m.states, state)
-	ctx.LocalStorage().Del(state)
+	m.computed[state] = &computedState{
+		compute: compute,
+		stale:   true,
+	}
+	m.mutex.Unlock()
+
+	m.invalidate(ctx, true, state)
+}
+
+// getComputed retrieves the value of the given computed state into the given
+// receiver, computing it when its dependencies changed. It returns false when
+// the state is not a computed state.
+func (m *stateManager) getComputed(ctx Context, state string, receiver any) bool {
+	m.mutex.Lock()
+	c, ok := m.computed[state]
+	if !ok {
+		m.mutex.Unlock()
+		return false
+	}
+
+	if !c.stale {
+		value := c.value
+		m.mutex.Unlock()
+
+		if err := storeValue(receiver, value); err != nil {
+			Log(errors.New("getting computed state failed").
+				WithTag("state", state).
+				Wrap(err))
+		}
+		return true
+	}
+
+	if m.computing[state] {
+		m.mutex.Unlock()
+		Log(errors.New("computing state failed").
+			WithTag("state", state).
+			WithTag("reason", "dependency cycle"))
+		return true
+	}
+	if m.computing == nil {
+		m.computing = make(map[string]bool)
+	}
+	m.computing[state] = true
+	m.mutex.Unlock()
+
+	dependencies := make(map[string]struct{})
+	computeCtx := ctx
+	computeCtx.getState = func(ctx Context, dependency string, receiver any) {
+		dependencies[dependency] = struct{}{}
+		m.Get(ctx, dependency, receiver)
+	}
+	value := c.compute(computeCtx)
+
+	m.mutex.Lock()
+	delete(m.computing, state)
+	if m.computed[state] == c {
+		c.value = value
+		c.stale = false
+		m.setDependencies(state, c, dependencies)
+	}
+	m.mutex.Unlock()
+
+	if err := storeValue(receiver, value); err != nil {
+		Log(errors.New("getting computed state failed").
+			WithTag("state", state).
+			Wrap(err))
+	}
+	return true
+}
+
+func (m *stateManager) setDependencies(state string, c *computedState, dependencies map[string]struct{}) {
+	m.removeDependencies(state, c)
+
+	if m.dependents == nil {
+		m.dependents = make(map[string]map[string]struct{})
+	}
+	c.dependencies = make([]string, 0, len(dependencies))
+	for dependency := range dependencies {
+		c.dependencies = append(c.dependencies, dependency)
+
+		dependents := m.dependents[dependency]
+		if dependents == nil {
+			dependents = make(map[string]struct{})
+			m.dependents[dependency] = dependents
+		}
+		dependents[state] = struct{}{}
+	}
+}
+
+func (m *stateManager) removeDependencies(state string, c *computedState) {
+	for _, dependency := range c.dependencies {
+		delete(m.dependents[dependency], state)
+		if len(m.dependents[dependency]) == 0 {
+			delete(m.dependents, dependency)
+		}
+	}
+	c.dependencies = nil
+}
+
+func (m *stateManager) removeComputed(state string) {
+	if c, ok := m.computed[state]; ok {
+		m.removeDependencies(state, c)
+		delete(m.computed, state)
+	}
+}
+
+// invalidate marks the computed states that depend on the given states as
+// stale and notifies their observers. The observers of the given states are
+// also notified when self is true.
+func (m *stateManager) invalidate(ctx Context, self bool, states ...string) {
+	m.mutex.Lock()
+
+	var invalidated []string
+	if self {
+		invalidated = append(invalidated, states...)
+	}
+	for queue := append([]string(nil), states...); len(queue) != 0; queue = queue[1:] {
+		for dependent := range m.dependents[queue[0]] {
+			if c := m.computed[dependent]; c != nil && !c.stale {
+				c.stale = true
+				invalidated = append(invalidated, dependent)
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	type notification struct {
+		state    string
+		observer Observer
+	}
+	var notifications []notification
+	for _, state := range invalidated {
+		for _, observer := range m.observers[state] {
+			notifications = append(notifications, notification{
+				state:    state,
+				observer: observer,
+			})
+		}
+	}
+
+	m.mutex.Unlock()
+
+	for _, n := range notifications {
+		state := n.state
+		o := n.observer
+		ctx.sourceElement = o.source
+
+		ctx.Dispatch(func(ctx Context) {
+			if !o.observing() {
+				m.mutex.Lock()
+				delete(m.observers[state], o.source)
+				m.mutex.Unlock()
+				return
+			}
+
+			m.Get(ctx, state, o.receiver)
+			if o.changeHandler != nil {
+				o.changeHandler()
+			}
+		})
+	}
 }
 
 // Cleanup removes observers that are no longer active and cleans up any states
@@ -436,14 +1459,26 @@ func (m *stateManager) Cleanup() {
 // CleanupExpiredPersistedStates traverses the local storage to identify and
 // remove any persisted states that have expired. This method ensures that the
 // local storage is kept clean by eliminating outdated or irrelevant state data.
+// The expiration of the other persisted states is scheduled when timed
+// expirations are enabled.
 func (m *stateManager) CleanupExpiredPersistedStates(ctx Context) {
-	ctx.LocalStorage().ForEach(func(key string) {
+	ctx.persistedStateStorage().ForEach(func(key string) {
 		var state storableState
-		ctx.LocalStorage().Get(key, &state)
-		if (len(state.Value) != 0 || len(state.EncryptedValue) != 0) &&
-			expiredTime(state.ExpiresAt) {
-			ctx.LocalStorage().Del(key)
+		ctx.persistedStateStorage().Get(key, &state)
+		if len(state.Value) == 0 && len(state.EncryptedValue) == 0 {
+			return
 		}
+
+		if expiredTime(state.ExpiresAt) {
+			ctx.persistedStateStorage().Del(key)
+			return
+		}
+
+		m.mutex.Lock()
+		if _, exists := m.states[key]; !exists {
+			m.scheduleExpiration(ctx, key, state.ExpiresAt)
+		}
+		m.mutex.Unlock()
 	})
 }
 
@@ -454,12 +1489,20 @@ func storeValue(recv, v any) error {
 	}
 	dst = dst.Elem()
 
+	if transferred, ok := v.(transferredValue); ok {
+		return json.Unmarshal(transferred, recv)
+	}
+
 	src := reflect.ValueOf(v)
 	switch {
 	case src == reflect.Value{}:
 		dst.Set(reflect.Zero(dst.Type()))
 		return nil
 
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+		return nil
+
 	case src.Kind() == reflect.Ptr:
 		src = src.Elem()
 	}
@@ -473,6 +1516,48 @@ func storeValue(recv, v any) error {
 	return nil
 }
 
+type stateHistory struct {
+	depth int
+	undos []historyStep
+	redos []historyStep
+}
+
+func (h *stateHistory) trim() {
+	if len(h.undos) > h.depth {
+		h.undos = append([]historyStep(nil), h.undos[len(h.undos)-h.depth:]...)
+	}
+}
+
+// historyStep is a value recorded in a state history. Steps recorded in the
+// same GroupHistory call share a non-zero group.
+type historyStep struct {
+	value any
+	group uint64
+}
+
+// stateBroadcast is a message that notifies other tabs about a state change or
+// an action.
+type stateBroadcast struct {
+	StoreID string
+	State   string `json:",omitempty"`
+	Action  string `json:",omitempty"`
+	Value   string
+	Tags    Tags `json:",omitempty"`
+}
+
+const stateBroadcastKey = "/go-app/broadcast"
+
+type computedState struct {
+	compute      func(Context) any
+	value        any
+	stale        bool
+	dependencies []string
+}
+
+// transferredValue is a state value transferred from the server, which is
+// decoded when retrieved.
+type transferredValue json.RawMessage
+
 func expiredTime(v time.Time) bool {
 	return !v.IsZero() && v.Before(time.Now())
 }
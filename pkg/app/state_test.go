@@ -1,11 +1,13 @@
 package app
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/maxence-charriere/go-app/v10/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -225,6 +227,44 @@ func TestStateManagerGet(t *testing.T) {
 		require.Equal(t, 42, number)
 	})
 
+	t.Run("getting a state from persisted state storage succeeds", func(t *testing.T) {
+		testSkipNonWasm(t)
+
+		storage := newMemoryStorage()
+		SetPersistedStateStorage(storage)
+		defer SetPersistedStateStorage(nil)
+
+		stateName := uuid.NewString()
+
+		var m stateManager
+		ctx := makeTestContext()
+		m.Set(ctx, stateName, 42).Persist()
+		delete(m.states, stateName)
+		require.True(t, storage.Contains(stateName))
+		require.False(t, ctx.LocalStorage().Contains(stateName))
+
+		var number int
+		m.Get(ctx, stateName, &number)
+		require.Equal(t, 42, number)
+	})
+
+	t.Run("getting a state persisted by another server engine returns nothing", func(t *testing.T) {
+		testSkipWasm(t)
+
+		SetPersistedStateStorage(NewIndexedDBStorage("states"))
+		defer SetPersistedStateStorage(nil)
+
+		stateName := uuid.NewString()
+
+		e1 := newTestEngine()
+		e1.states.Set(e1.baseContext(), stateName, "alice-secret").Persist()
+
+		e2 := newTestEngine()
+		var secret string
+		e2.states.Get(e2.baseContext(), stateName, &secret)
+		require.Empty(t, secret)
+	})
+
 	t.Run("getting an expired state removes the state from state manager and local storage", func(t *testing.T) {
 		stateName := uuid.NewString()
 
@@ -305,6 +345,183 @@ func TestStateManagerGet(t *testing.T) {
 	})
 }
 
+func TestStateManagerGetWithSchema(t *testing.T) {
+	type counter struct {
+		Count int
+	}
+
+	migrations := map[int]func(json.RawMessage) (json.RawMessage, error){
+		0: func(v json.RawMessage) (json.RawMessage, error) {
+			var count int
+			if err := json.Unmarshal(v, &count); err != nil {
+				return nil, err
+			}
+			return json.Marshal(counter{Count: count})
+		},
+	}
+
+	persistWithoutSchema := func(ctx Context, state string, encrypt bool) {
+		var m stateManager
+		if encrypt {
+			m.Set(ctx, state, 42).PersistWithEncryption()
+		} else {
+			m.Set(ctx, state, 42).Persist()
+		}
+	}
+
+	t.Run("state persisted with a previous version is migrated", func(t *testing.T) {
+		stateName := uuid.NewString()
+		ctx := makeTestContext()
+		persistWithoutSchema(ctx, stateName, false)
+
+		RegisterStateSchema(stateName, StateSchema{
+			Version:    1,
+			Migrations: migrations,
+		})
+		defer delete(stateSchemas, stateName)
+
+		var m stateManager
+		var c counter
+		m.Get(ctx, stateName, &c)
+		require.Equal(t, 42, c.Count)
+
+		var stored storableState
+		err := ctx.LocalStorage().Get(stateName, &stored)
+		require.NoError(t, err)
+		require.Equal(t, 1, stored.Version)
+		require.JSONEq(t, `{"Count":42}`, string(stored.Value))
+	})
+
+	t.Run("encrypted state persisted with a previous version is migrated", func(t *testing.T) {
+		stateName := uuid.NewString()
+		ctx := makeTestContext()
+		persistWithoutSchema(ctx, stateName, true)
+
+		RegisterStateSchema(stateName, StateSchema{
+			Version:    1,
+			Migrations: migrations,
+		})
+		defer delete(stateSchemas, stateName)
+
+		var m stateManager
+		var c counter
+		m.Get(ctx, stateName, &c)
+		require.Equal(t, 42, c.Count)
+
+		c = counter{}
+		m.Get(ctx, stateName, &c)
+		require.Equal(t, 42, c.Count)
+	})
+
+	t.Run("state is persisted with the schema version", func(t *testing.T) {
+		stateName := uuid.NewString()
+		RegisterStateSchema(stateName, StateSchema{Version: 3})
+		defer delete(stateSchemas, stateName)
+
+		var m stateManager
+		ctx := makeTestContext()
+		m.Set(ctx, stateName, counter{Count: 21}).Persist()
+
+		var stored storableState
+		err := ctx.LocalStorage().Get(stateName, &stored)
+		require.NoError(t, err)
+		require.Equal(t, 3, stored.Version)
+
+		delete(m.states, stateName)
+		var c counter
+		m.Get(ctx, stateName, &c)
+		require.Equal(t, 21, c.Count)
+	})
+
+	t.Run("invalid state is kept", func(t *testing.T) {
+		stateName := uuid.NewString()
+		ctx := makeTestContext()
+		persistWithoutSchema(ctx, stateName, false)
+
+		RegisterStateSchema(stateName, StateSchema{Version: 1})
+		defer delete(stateSchemas, stateName)
+
+		var m stateManager
+		var c counter
+		m.Get(ctx, stateName, &c)
+		require.Zero(t, c.Count)
+
+		var stored storableState
+		err := ctx.LocalStorage().Get(stateName, &stored)
+		require.NoError(t, err)
+		require.NotEmpty(t, stored.Value)
+	})
+
+	t.Run("invalid state is dropped", func(t *testing.T) {
+		stateName := uuid.NewString()
+		ctx := makeTestContext()
+		persistWithoutSchema(ctx, stateName, false)
+
+		RegisterStateSchema(stateName, StateSchema{
+			InvalidValuePolicy: DropInvalidStateValue,
+		})
+		defer delete(stateSchemas, stateName)
+
+		var m stateManager
+		var c counter
+		m.Get(ctx, stateName, &c)
+		require.Zero(t, c.Count)
+
+		var stored storableState
+		err := ctx.LocalStorage().Get(stateName, &stored)
+		require.NoError(t, err)
+		require.Empty(t, stored.Value)
+	})
+
+	t.Run("invalid state is handled", func(t *testing.T) {
+		stateName := uuid.NewString()
+		ctx := makeTestContext()
+		persistWithoutSchema(ctx, stateName, false)
+
+		var invalidValue json.RawMessage
+		var invalidErr error
+		RegisterStateSchema(stateName, StateSchema{
+			Version: 2,
+			OnInvalidValue: func(ctx Context, state string, v json.RawMessage, err error) {
+				invalidValue = v
+				invalidErr = err
+			},
+		})
+		defer delete(stateSchemas, stateName)
+
+		var m stateManager
+		var c counter
+		m.Get(ctx, stateName, &c)
+		require.Zero(t, c.Count)
+		require.Equal(t, "42", string(invalidValue))
+		require.Error(t, invalidErr)
+	})
+
+	t.Run("state persisted with a greater version is invalid", func(t *testing.T) {
+		stateName := uuid.NewString()
+		ctx := makeTestContext()
+
+		RegisterStateSchema(stateName, StateSchema{Version: 2})
+		var m stateManager
+		m.Set(ctx, stateName, counter{Count: 42}).Persist()
+
+		var invalidErr error
+		RegisterStateSchema(stateName, StateSchema{
+			Version: 1,
+			OnInvalidValue: func(ctx Context, state string, v json.RawMessage, err error) {
+				invalidErr = err
+			},
+		})
+		defer delete(stateSchemas, stateName)
+
+		delete(m.states, stateName)
+		var c counter
+		m.Get(ctx, stateName, &c)
+		require.Zero(t, c.Count)
+		require.Error(t, invalidErr)
+	})
+}
+
 func TestStateManagerSet(t *testing.T) {
 	t.Run("state is set", func(t *testing.T) {
 		stateName := uuid.NewString()
@@ -667,6 +884,713 @@ func TestStateManagerSet(t *testing.T) {
 	})
 }
 
+func TestStateManagerTimedExpiration(t *testing.T) {
+	stateRemoved := func(m *stateManager, state string) func() bool {
+		return func() bool {
+			m.mutex.RLock()
+			defer m.mutex.RUnlock()
+			_, exists := m.states[state]
+			return !exists
+		}
+	}
+
+	t.Run("observers are notified when state expires", func(t *testing.T) {
+		e := newTestEngine()
+		ctx := e.baseContext()
+
+		var nm nodeManager
+		compo, err := nm.Mount(ctx, 1, &hello{})
+		require.NoError(t, err)
+		ctx = nm.context(ctx, compo)
+
+		stateName := uuid.NewString()
+		sm := stateManager{timedExpirations: true}
+		sm.Set(ctx, stateName, 42).
+			ExpiresIn(10 * time.Millisecond).
+			Persist()
+
+		var v int
+		var changed, expired bool
+		sm.Observe(ctx, stateName, &v).
+			OnChange(func() {
+				changed = true
+			}).
+			OnExpire(func() {
+				expired = true
+			})
+		require.Equal(t, 42, v)
+
+		require.Eventually(t, stateRemoved(&sm, stateName), time.Second, time.Millisecond)
+		e.ConsumeAll()
+		require.Zero(t, v)
+		require.True(t, changed)
+		require.True(t, expired)
+		require.False(t, ctx.LocalStorage().Contains(stateName))
+	})
+
+	t.Run("setting state cancels expiration", func(t *testing.T) {
+		var m stateManager
+		m.timedExpirations = true
+		ctx := makeTestContext()
+
+		m.Set(ctx, "/expiration", 42).ExpiresIn(5 * time.Millisecond)
+		m.Set(ctx, "/expiration", 21)
+		time.Sleep(20 * time.Millisecond)
+
+		var v int
+		m.Get(ctx, "/expiration", &v)
+		require.Equal(t, 21, v)
+		require.Empty(t, m.expirations)
+	})
+
+	t.Run("expiration is not scheduled when timed expirations are disabled", func(t *testing.T) {
+		var m stateManager
+		ctx := makeTestContext()
+
+		m.Set(ctx, "/expiration", 42).ExpiresIn(time.Minute)
+		require.Empty(t, m.expirations)
+	})
+
+	t.Run("persisted state expiration is scheduled on cleanup", func(t *testing.T) {
+		stateName := uuid.NewString()
+		ctx := makeTestContext()
+
+		var m stateManager
+		m.Set(ctx, stateName, 42).
+			ExpiresIn(10 * time.Millisecond).
+			Persist()
+		delete(m.states, stateName)
+
+		m.timedExpirations = true
+		m.CleanupExpiredPersistedStates(ctx)
+		require.Eventually(t, func() bool {
+			return !ctx.LocalStorage().Contains(stateName)
+		}, time.Second, time.Millisecond)
+	})
+}
+
+func TestStateManagerHistory(t *testing.T) {
+	t.Run("state is undone and redone", func(t *testing.T) {
+		var m stateManager
+		ctx := makeTestContext()
+		m.Set(ctx, "/history", 1).WithHistory(10)
+		m.Set(ctx, "/history", 2)
+		m.Set(ctx, "/history", 3)
+
+		var v int
+		require.True(t, m.CanUndo("/history"))
+		require.False(t, m.CanRedo("/history"))
+
+		m.Undo(ctx, "/history")
+		m.Get(ctx, "/history", &v)
+		require.Equal(t, 2, v)
+
+		m.Undo(ctx, "/history")
+		m.Get(ctx, "/history", &v)
+		require.Equal(t, 1, v)
+		require.False(t, m.CanUndo("/history"))
+		require.True(t, m.CanRedo("/history"))
+
+		m.Undo(ctx, "/history")
+		m.Get(ctx, "/history", &v)
+		require.Equal(t, 1, v)
+
+		m.Redo(ctx, "/history")
+		m.Get(ctx, "/history", &v)
+		require.Equal(t, 2, v)
+
+		m.Set(ctx, "/history", 42)
+		require.False(t, m.CanRedo("/history"))
+		m.Undo(ctx, "/history")
+		m.Get(ctx, "/history", &v)
+		require.Equal(t, 2, v)
+	})
+
+	t.Run("history depth is bounded", func(t *testing.T) {
+		var m stateManager
+		ctx := makeTestContext()
+		m.Set(ctx, "/history", 0).WithHistory(2)
+		for i := 1; i <= 5; i++ {
+			m.Set(ctx, "/history", i)
+		}
+
+		m.Undo(ctx, "/history")
+		m.Undo(ctx, "/history")
+		require.False(t, m.CanUndo("/history"))
+
+		var v int
+		m.Get(ctx, "/history", &v)
+		require.Equal(t, 3, v)
+	})
+
+	t.Run("state without history is not undone", func(t *testing.T) {
+		var m stateManager
+		ctx := makeTestContext()
+		m.Set(ctx, "/history", 1)
+		m.Set(ctx, "/history", 2)
+		require.False(t, m.CanUndo("/history"))
+
+		m.Undo(ctx, "/history")
+		var v int
+		m.Get(ctx, "/history", &v)
+		require.Equal(t, 2, v)
+	})
+
+	t.Run("grouped changes are undone at once", func(t *testing.T) {
+		var m stateManager
+		ctx := makeTestContext()
+		m.Set(ctx, "/history/a", "a0").WithHistory(10)
+		m.Set(ctx, "/history/b", "b0").WithHistory(10)
+
+		m.GroupHistory(func() {
+			m.Set(ctx, "/history/a", "a1")
+			m.Set(ctx, "/history/a", "a2")
+			m.GroupHistory(func() {
+				m.Set(ctx, "/history/b", "b1")
+			})
+			m.Set(ctx, "/history/b", "b2")
+		})
+		require.Nil(t, m.historyGroup)
+
+		var a, b string
+		m.Undo(ctx, "/history/a")
+		m.Get(ctx, "/history/a", &a)
+		m.Get(ctx, "/history/b", &b)
+		require.Equal(t, "a0", a)
+		require.Equal(t, "b0", b)
+		require.False(t, m.CanUndo("/history/a"))
+		require.False(t, m.CanUndo("/history/b"))
+
+		m.Redo(ctx, "/history/b")
+		m.Get(ctx, "/history/a", &a)
+		m.Get(ctx, "/history/b", &b)
+		require.Equal(t, "a2", a)
+		require.Equal(t, "b2", b)
+		require.False(t, m.CanRedo("/history/a"))
+		require.False(t, m.CanRedo("/history/b"))
+	})
+
+	t.Run("ungrouped changes are undone separately", func(t *testing.T) {
+		var m stateManager
+		ctx := makeTestContext()
+		m.Set(ctx, "/history/a", "a0").WithHistory(10)
+		m.Set(ctx, "/history/b", "b0").WithHistory(10)
+
+		m.GroupHistory(func() {
+			m.Set(ctx, "/history/a", "a1")
+			m.Set(ctx, "/history/b", "b1")
+		})
+		m.Set(ctx, "/history/b", "b2")
+
+		var a, b string
+		m.Undo(ctx, "/history/b")
+		m.Get(ctx, "/history/a", &a)
+		m.Get(ctx, "/history/b", &b)
+		require.Equal(t, "a1", a)
+		require.Equal(t, "b1", b)
+
+		m.Undo(ctx, "/history/a")
+		m.Get(ctx, "/history/a", &a)
+		m.Get(ctx, "/history/b", &b)
+		require.Equal(t, "a0", a)
+		require.Equal(t, "b0", b)
+	})
+
+	t.Run("observers are notified of undone state", func(t *testing.T) {
+		e := newTestEngine()
+		ctx := e.baseContext()
+
+		var nm nodeManager
+		compo, err := nm.Mount(ctx, 1, &hello{})
+		require.NoError(t, err)
+		ctx = nm.context(ctx, compo)
+
+		var sm stateManager
+		sm.Set(ctx, "/history", 1).WithHistory(10)
+		sm.Set(ctx, "/history", 2)
+
+		var v int
+		sm.Observe(ctx, "/history", &v)
+		require.Equal(t, 2, v)
+
+		sm.Undo(ctx, "/history")
+		e.ConsumeAll()
+		require.Equal(t, 1, v)
+	})
+
+	t.Run("deleting state removes history", func(t *testing.T) {
+		var m stateManager
+		ctx := makeTestContext()
+		m.Set(ctx, "/history", 1).WithHistory(10)
+		m.Set(ctx, "/history", 2)
+		m.Delete(ctx, "/history")
+		require.False(t, m.CanUndo("/history"))
+	})
+}
+
+func TestStateManagerTransaction(t *testing.T) {
+	t.Run("changes are applied when committed", func(t *testing.T) {
+		var m stateManager
+		ctx := makeTestContext()
+		m.Set(ctx, "/tx/a", 1)
+		m.Set(ctx, "/tx/c", 3)
+
+		err := m.Transaction(ctx, func(tx *StateTransaction) error {
+			var a int
+			tx.Get("/tx/a", &a)
+			tx.Set("/tx/a", a+1)
+			tx.Set("/tx/b", 2)
+			tx.Delete("/tx/c")
+
+			tx.Get("/tx/a", &a)
+			require.Equal(t, 2, a)
+
+			m.Get(ctx, "/tx/a", &a)
+			require.Equal(t, 1, a)
+			return nil
+		})
+		require.NoError(t, err)
+
+		var a, b int
+		m.Get(ctx, "/tx/a", &a)
+		m.Get(ctx, "/tx/b", &b)
+		require.Equal(t, 2, a)
+		require.Equal(t, 2, b)
+		require.NotContains(t, m.states, "/tx/c")
+	})
+
+	t.Run("changes are rolled back on error", func(t *testing.T) {
+		var m stateManager
+		ctx := makeTestContext()
+		m.Set(ctx, "/tx/a", 1)
+
+		err := m.Transaction(ctx, func(tx *StateTransaction) error {
+			tx.Set("/tx/a", 2)
+			tx.Delete("/tx/a")
+			return errors.New("test")
+		})
+		require.Error(t, err)
+
+		var a int
+		m.Get(ctx, "/tx/a", &a)
+		require.Equal(t, 1, a)
+	})
+
+	t.Run("observers are notified once", func(t *testing.T) {
+		e := newTestEngine()
+		ctx := e.baseContext()
+
+		var nm nodeManager
+		compo, err := nm.Mount(ctx, 1, &hello{})
+		require.NoError(t, err)
+		ctx = nm.context(ctx, compo)
+
+		var computations int
+		var sm stateManager
+		sm.Set(ctx, "/tx/a", 1)
+		sm.Set(ctx, "/tx/b", 2)
+		sm.Compute(ctx, "/tx/sum", func(ctx Context) any {
+			computations++
+			var a, b int
+			ctx.GetState("/tx/a", &a)
+			ctx.GetState("/tx/b", &b)
+			return a + b
+		})
+
+		var a, sum int
+		var changes, sumChanges int
+		sm.Observe(ctx, "/tx/a", &a).OnChange(func() {
+			changes++
+		})
+		sm.Observe(ctx, "/tx/sum", &sum).OnChange(func() {
+			sumChanges++
+		})
+		require.Equal(t, 3, sum)
+
+		err = sm.Transaction(ctx, func(tx *StateTransaction) error {
+			tx.Set("/tx/a", 10)
+			tx.Set("/tx/a", 20)
+			tx.Set("/tx/b", 22)
+			return nil
+		})
+		require.NoError(t, err)
+		e.ConsumeAll()
+
+		require.Equal(t, 20, a)
+		require.Equal(t, 42, sum)
+		require.Equal(t, 1, changes)
+		require.Equal(t, 1, sumChanges)
+		require.Equal(t, 2, computations)
+	})
+
+	t.Run("committed changes are recorded as one history step", func(t *testing.T) {
+		var m stateManager
+		ctx := makeTestContext()
+		m.Set(ctx, "/tx/a", 1).WithHistory(10)
+
+		err := m.Transaction(ctx, func(tx *StateTransaction) error {
+			tx.Set("/tx/a", 2)
+			tx.Set("/tx/a", 3)
+			return nil
+		})
+		require.NoError(t, err)
+
+		var a int
+		m.Undo(ctx, "/tx/a")
+		m.Get(ctx, "/tx/a", &a)
+		require.Equal(t, 1, a)
+		require.False(t, m.CanUndo("/tx/a"))
+	})
+}
+
+func TestStateManagerCompute(t *testing.T) {
+	sum := func(computations *int, a, b string) func(Context) any {
+		return func(ctx Context) any {
+			*computations++
+			var x, y int
+			ctx.GetState(a, &x)
+			ctx.GetState(b, &y)
+			return x + y
+		}
+	}
+
+	t.Run("computed state is lazily computed", func(t *testing.T) {
+		var m stateManager
+		ctx := makeTestContext()
+
+		var computations int
+		m.Set(ctx, "/a", 2)
+		m.Set(ctx, "/b", 3)
+		m.Compute(ctx, "/sum", sum(&computations, "/a", "/b"))
+		require.Zero(t, computations)
+
+		var value int
+		m.Get(ctx, "/sum", &value)
+		require.Equal(t, 5, value)
+		m.Get(ctx, "/sum", &value)
+		require.Equal(t, 1, computations)
+
+		m.Set(ctx, "/a", 10)
+		require.Equal(t, 1, computations)
+		m.Get(ctx, "/sum", &value)
+		require.Equal(t, 13, value)
+		require.Equal(t, 2, computations)
+	})
+
+	t.Run("computed state depending on computed state is computed", func(t *testing.T) {
+		var m stateManager
+		ctx := makeTestContext()
+
+		var computations int
+		m.Set(ctx, "/a", 1)
+		m.Set(ctx, "/b", 2)
+		m.Compute(ctx, "/ab", sum(&computations, "/a", "/b"))
+		m.Compute(ctx, "/abb", sum(&computations, "/ab", "/b"))
+
+		var value int
+		m.Get(ctx, "/abb", &value)
+		require.Equal(t, 5, value)
+
+		m.Set(ctx, "/a", 11)
+		m.Get(ctx, "/abb", &value)
+		require.Equal(t, 15, value)
+		require.Equal(t, 4, computations)
+	})
+
+	t.Run("computed state observers are notified", func(t *testing.T) {
+		e := newTestEngine()
+		ctx := e.baseContext()
+
+		var nm nodeManager
+		compo, err := nm.Mount(ctx, 1, &hello{})
+		require.NoError(t, err)
+		ctx = nm.context(ctx, compo)
+
+		var computations int
+		var sm stateManager
+		sm.Set(ctx, "/a", 2)
+		sm.Set(ctx, "/b", 3)
+		sm.Compute(ctx, "/sum", sum(&computations, "/a", "/b"))
+
+		var value int
+		var changes int
+		sm.Observe(ctx, "/sum", &value).OnChange(func() {
+			changes++
+		})
+		require.Equal(t, 5, value)
+
+		sm.Set(ctx, "/b", 40)
+		e.ConsumeAll()
+		require.Equal(t, 42, value)
+		require.Equal(t, 1, changes)
+	})
+
+	t.Run("computed state with dependency cycle is logged", func(t *testing.T) {
+		var m stateManager
+		ctx := makeTestContext()
+
+		var computations int
+		m.Set(ctx, "/a", 1)
+		m.Compute(ctx, "/b", sum(&computations, "/a", "/c"))
+		m.Compute(ctx, "/c", sum(&computations, "/a", "/b"))
+
+		var value int
+		m.Get(ctx, "/b", &value)
+		require.Equal(t, 2, value)
+		require.Equal(t, 2, computations)
+		require.Empty(t, m.computing)
+	})
+
+	t.Run("set state removes computed state", func(t *testing.T) {
+		var m stateManager
+		ctx := makeTestContext()
+
+		var computations int
+		m.Set(ctx, "/a", 1)
+		m.Compute(ctx, "/sum", sum(&computations, "/a", "/a"))
+
+		var value int
+		m.Get(ctx, "/sum", &value)
+		require.Equal(t, 2, value)
+
+		m.Set(ctx, "/sum", 21)
+		require.Empty(t, m.computed)
+		require.Empty(t, m.dependents)
+		m.Get(ctx, "/sum", &value)
+		require.Equal(t, 21, value)
+	})
+
+	t.Run("delete state removes computed state", func(t *testing.T) {
+		var m stateManager
+		ctx := makeTestContext()
+
+		var computations int
+		m.Compute(ctx, "/sum", sum(&computations, "/a", "/a"))
+		m.Delete(ctx, "/sum")
+		require.Empty(t, m.computed)
+	})
+}
+
+func TestStateManagerTransfer(t *testing.T) {
+	type user struct {
+		Name string
+	}
+
+	t.Run("transferred states are restored", func(t *testing.T) {
+		var server stateManager
+		ctx := makeTestContext()
+
+		server.Set(ctx, "/user", user{Name: "Maxence"}).Transfer()
+		server.Set(ctx, "/count", 42).
+			Transfer().
+			ExpiresIn(time.Minute)
+		server.Set(ctx, "/private", "secret")
+		server.Set(ctx, "/expired", 21).
+			ExpiresAt(time.Now().Add(-time.Minute)).
+			Transfer()
+
+		states, err := server.TransferredStates()
+		require.NoError(t, err)
+		require.NotContains(t, string(states), "secret")
+
+		var client stateManager
+		err = client.RestoreTransferredStates(states)
+		require.NoError(t, err)
+		require.Len(t, client.states, 2)
+
+		var u user
+		client.Get(ctx, "/user", &u)
+		require.Equal(t, "Maxence", u.Name)
+
+		var count int
+		client.Get(ctx, "/count", &count)
+		require.Equal(t, 42, count)
+		require.False(t, client.states["/count"].expiresAt.IsZero())
+	})
+
+	t.Run("no transferred states returns nil", func(t *testing.T) {
+		var m stateManager
+		m.Set(makeTestContext(), "/private", "secret")
+
+		states, err := m.TransferredStates()
+		require.NoError(t, err)
+		require.Nil(t, states)
+	})
+
+	t.Run("transferred state not encodable returns an error", func(t *testing.T) {
+		var m stateManager
+		m.Set(makeTestContext(), "/func", func() {}).Transfer()
+
+		_, err := m.TransferredStates()
+		require.Error(t, err)
+	})
+
+	t.Run("restoring invalid states returns an error", func(t *testing.T) {
+		var m stateManager
+		err := m.RestoreTransferredStates([]byte("{"))
+		require.Error(t, err)
+	})
+}
+
+func TestStateManagerStorageSync(t *testing.T) {
+	setup := func(t *testing.T) (*engineX, Context) {
+		e := newTestEngine()
+		ctx := e.baseContext()
+
+		var nm nodeManager
+		compo, err := nm.Mount(ctx, 1, &hello{})
+		require.NoError(t, err)
+		return e, nm.context(ctx, compo)
+	}
+
+	persistFromOtherTab := func(t *testing.T, ctx Context, state string, v any, updatedAt time.Time) string {
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+
+		value := storableState{
+			Value:     b,
+			UpdatedAt: updatedAt,
+		}
+		err = ctx.LocalStorage().Set(state, value)
+		require.NoError(t, err)
+
+		b, err = json.Marshal(value)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	t.Run("observer is notified of persisted state changed by another tab", func(t *testing.T) {
+		e, ctx := setup(t)
+		stateName := uuid.NewString()
+
+		var sm stateManager
+		sm.Set(ctx, stateName, 21).Persist()
+
+		var v int
+		var changes int
+		sm.Observe(ctx, stateName, &v).
+			WithStorageSync().
+			OnChange(func() {
+				changes++
+			})
+
+		newValue := persistFromOtherTab(t, ctx, stateName, 42, time.Now().Add(time.Second))
+		sm.handleStorageChange(ctx, stateName, newValue)
+		e.ConsumeAll()
+		require.Equal(t, 42, v)
+		require.Equal(t, 1, changes)
+
+		var current int
+		sm.Get(ctx, stateName, &current)
+		require.Equal(t, 42, current)
+	})
+
+	t.Run("persisted state changed earlier by another tab is overwritten", func(t *testing.T) {
+		e, ctx := setup(t)
+		stateName := uuid.NewString()
+
+		var sm stateManager
+		sm.Set(ctx, stateName, 21).Persist()
+
+		var v int
+		sm.Observe(ctx, stateName, &v).WithStorageSync()
+
+		newValue := persistFromOtherTab(t, ctx, stateName, 42, time.Now().Add(-time.Minute))
+		sm.handleStorageChange(ctx, stateName, newValue)
+		e.ConsumeAll()
+		require.Equal(t, 21, v)
+
+		var current int
+		sm.Get(ctx, stateName, &current)
+		require.Equal(t, 21, current)
+
+		var stored storableState
+		err := ctx.LocalStorage().Get(stateName, &stored)
+		require.NoError(t, err)
+		require.JSONEq(t, "21", string(stored.Value))
+	})
+
+	t.Run("encrypted persisted state changed earlier by another tab is overwritten", func(t *testing.T) {
+		e, ctx := setup(t)
+		stateName := uuid.NewString()
+
+		var sm stateManager
+		sm.Set(ctx, stateName, 21).PersistWithEncryption()
+
+		newValue := persistFromOtherTab(t, ctx, stateName, 42, time.Now().Add(-time.Minute))
+		sm.handleStorageChange(ctx, stateName, newValue)
+		e.ConsumeAll()
+
+		delete(sm.states, stateName)
+		var current int
+		sm.Get(ctx, stateName, &current)
+		require.Equal(t, 21, current)
+	})
+
+	t.Run("observer is notified of persisted state removed by another tab", func(t *testing.T) {
+		e, ctx := setup(t)
+		stateName := uuid.NewString()
+
+		var sm stateManager
+		sm.Set(ctx, stateName, 21).Persist()
+
+		var v int
+		sm.Observe(ctx, stateName, &v).WithStorageSync()
+
+		ctx.LocalStorage().Del(stateName)
+		sm.handleStorageChange(ctx, stateName, "")
+		e.ConsumeAll()
+		require.Zero(t, v)
+		require.NotContains(t, sm.states, stateName)
+	})
+
+	t.Run("observer without storage sync is not notified", func(t *testing.T) {
+		e, ctx := setup(t)
+		stateName := uuid.NewString()
+
+		var sm stateManager
+		sm.Set(ctx, stateName, 21).Persist()
+
+		var v int
+		sm.Observe(ctx, stateName, &v)
+
+		newValue := persistFromOtherTab(t, ctx, stateName, 42, time.Now().Add(time.Second))
+		sm.handleStorageChange(ctx, stateName, newValue)
+		e.ConsumeAll()
+		require.Equal(t, 21, v)
+	})
+
+	t.Run("non state storage item is ignored", func(t *testing.T) {
+		e, ctx := setup(t)
+
+		var sm stateManager
+		sm.Set(ctx, "/hello", 21)
+		sm.handleStorageChange(ctx, "/hello", `"world"`)
+		e.ConsumeAll()
+		require.Contains(t, sm.states, "/hello")
+	})
+
+	t.Run("broadcast sent with storage events is handled", func(t *testing.T) {
+		e, ctx := setup(t)
+		stateName := uuid.NewString()
+
+		var sm stateManager
+		var v int
+		sm.Observe(ctx, stateName, &v).WithBroadcast()
+
+		msg, err := json.Marshal(stateBroadcast{
+			StoreID: uuid.NewString(),
+			State:   stateName,
+			Value:   "42",
+		})
+		require.NoError(t, err)
+
+		sm.handleStorageChange(ctx, stateBroadcastKey, string(msg))
+		e.ConsumeAll()
+		require.Equal(t, 42, v)
+	})
+}
+
 func TestStateManagerDelete(t *testing.T) {
 	t.Run("state is deleted from memory", func(t *testing.T) {
 		stateName := uuid.NewString()
@@ -823,6 +1747,18 @@ func TestStoreValue(t *testing.T) {
 			recv:     &c.pointer,
 			expected: (*int)(nil),
 		},
+		{
+			scenario: "pointer to pointer receiver",
+			src:      &nb,
+			recv:     &c.pointer,
+			expected: &nb,
+		},
+		{
+			scenario: "value to interface receiver",
+			src:      42,
+			recv:     &c.iface,
+			expected: 42,
+		},
 		{
 			scenario: "slice to receiver",
 			src:      []int{14, 2, 86},
@@ -870,4 +1806,87 @@ type copyTester struct {
 	pointer    *int
 	slice      []int
 	mapp       map[string]int
+	iface      any
+}
+
+func TestStateManagerBroadcastAction(t *testing.T) {
+	setup := func(t *testing.T, action string) (*engineX, Context, *[]Action) {
+		e := newTestEngine()
+		e.actions.broadcasts = map[string]bool{action: true}
+
+		compo := &hello{}
+		err := e.Load(compo)
+		require.NoError(t, err)
+
+		var broadcasted []Action
+		ctx := e.nodes.context(e.baseContext(), compo)
+		ctx.broadcastAction = func(ctx Context, a Action) {
+			broadcasted = append(broadcasted, a)
+		}
+		return e, ctx, &broadcasted
+	}
+
+	t.Run("posted action is broadcasted", func(t *testing.T) {
+		action := uuid.NewString()
+		e, ctx, broadcasted := setup(t, action)
+
+		ctx.NewActionWithValue(action, 42, T("hello", "world"))
+		ctx.NewAction(uuid.NewString())
+		e.ConsumeAll()
+
+		require.Len(t, *broadcasted, 1)
+		require.Equal(t, action, (*broadcasted)[0].Name)
+		require.Equal(t, 42, (*broadcasted)[0].Value)
+		require.Equal(t, "world", (*broadcasted)[0].Tags.Get("hello"))
+	})
+
+	t.Run("action broadcasted by another tab is handled", func(t *testing.T) {
+		action := uuid.NewString()
+		e, ctx, broadcasted := setup(t, action)
+
+		var received Action
+		ctx.Handle(action, func(ctx Context, a Action) {
+			received = a
+		})
+
+		var sm stateManager
+		sm.BroadcastAction(ctx, Action{
+			Name:  action,
+			Value: 42,
+			Tags:  Tags{"hello": "world"},
+		})
+		msg, err := json.Marshal(stateBroadcast{
+			StoreID: uuid.NewString(),
+			Action:  action,
+			Value:   "42",
+			Tags:    Tags{"hello": "world"},
+		})
+		require.NoError(t, err)
+
+		sm.handleStorageChange(ctx, stateBroadcastKey, string(msg))
+		e.ConsumeAll()
+		require.Equal(t, action, received.Name)
+		require.Equal(t, json.RawMessage("42"), received.Value)
+		require.Equal(t, "world", received.Tags.Get("hello"))
+		require.Empty(t, *broadcasted)
+	})
+
+	t.Run("action broadcasted by the same tab is ignored", func(t *testing.T) {
+		action := uuid.NewString()
+		e, ctx, _ := setup(t, action)
+
+		var handled bool
+		ctx.Handle(action, func(ctx Context, a Action) {
+			handled = true
+		})
+
+		var sm stateManager
+		sm.initBroadcast(ctx)
+		sm.handleBroadcast(ctx, stateBroadcast{
+			StoreID: sm.broadcastStoreID,
+			Action:  action,
+		})
+		e.ConsumeAll()
+		require.False(t, handled)
+	})
 }
@@ -0,0 +1,104 @@
+package app
+
+// StateKey identifies a state whose value is of type T. It provides a
+// type-safe alternative to the Context state methods, which take receivers of
+// any type and only report type mismatches at runtime.
+//
+// T can be any type, including pointers and interfaces. Values that are
+// persisted or transferred from the server are decoded from JSON, which
+// restores interface values as generic JSON values such as map[string]any.
+//
+// Example:
+//
+//	var userKey = app.NewStateKey[User]("/user")
+//
+//	func (c *profile) OnMount(ctx app.Context) {
+//	    userKey.Observe(ctx, &c.user).OnChange(func(old, new User) {
+//	        app.Log("user changed from", old.Name, "to", new.Name)
+//	    })
+//	}
+type StateKey[T any] struct {
+	name string
+}
+
+// NewStateKey creates a key for the state with the given name.
+func NewStateKey[T any](name string) StateKey[T] {
+	return StateKey[T]{name: name}
+}
+
+// Name returns the name of the state.
+func (k StateKey[T]) Name() string {
+	return k.name
+}
+
+// Get returns the value of the state. The zero value of T is returned when the
+// state is not set.
+func (k StateKey[T]) Get(ctx Context) T {
+	var v T
+	ctx.GetState(k.name, &v)
+	return v
+}
+
+// Set modifies the state with the given value.
+func (k StateKey[T]) Set(ctx Context, v T) State {
+	return ctx.SetState(k.name, v)
+}
+
+// Delete erases the state, halting all associated observations.
+func (k StateKey[T]) Delete(ctx Context) {
+	ctx.DelState(k.name)
+}
+
+// Compute defines the state value as computed by the given function from other
+// states. See Context.ComputeState for details.
+func (k StateKey[T]) Compute(ctx Context, compute func(Context) T) {
+	ctx.ComputeState(k.name, func(ctx Context) any {
+		return compute(ctx)
+	})
+}
+
+// Observe establishes an observer that stores the state value into the given
+// receiver each time it changes.
+func (k StateKey[T]) Observe(ctx Context, receiver *T) StateObserver[T] {
+	return StateObserver[T]{
+		observer: ctx.ObserveState(k.name, receiver),
+		receiver: receiver,
+	}
+}
+
+// StateObserver is an Observer whose receiver is of type T.
+type StateObserver[T any] struct {
+	observer Observer
+	receiver *T
+}
+
+// While sets a condition for the observer, determining whether it observes
+// the state. Observation stops when the condition returns false.
+func (o StateObserver[T]) While(condition func() bool) StateObserver[T] {
+	o.observer = o.observer.While(condition)
+	return o
+}
+
+// OnChange sets a callback function to be executed each time the observer
+// detects a change in the state value. The callback is called with the
+// previous and the new values. Note that previous values are shallow copies:
+// slices, maps and pointers share their content with the new values when they
+// are modified in place.
+func (o StateObserver[T]) OnChange(h func(old, new T)) StateObserver[T] {
+	previous := *o.receiver
+	receiver := o.receiver
+
+	o.observer = o.observer.OnChange(func() {
+		old := previous
+		previous = *receiver
+		h(old, previous)
+	})
+	return o
+}
+
+// WithBroadcast enables the observer to listen to state changes that are
+// broadcasted by other browser tabs or windows.
+func (o StateObserver[T]) WithBroadcast() StateObserver[T] {
+	o.observer = o.observer.WithBroadcast()
+	return o
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateKey(t *testing.T) {
+	t.Run("state is set and retrieved", func(t *testing.T) {
+		e := newTestEngine()
+		ctx := e.baseContext()
+		key := NewStateKey[int]("/test/statekey/get")
+
+		require.Equal(t, "/test/statekey/get", key.Name())
+		require.Zero(t, key.Get(ctx))
+
+		key.Set(ctx, 42)
+		require.Equal(t, 42, key.Get(ctx))
+
+		key.Delete(ctx)
+		require.Zero(t, key.Get(ctx))
+	})
+
+	t.Run("pointer state is set and retrieved", func(t *testing.T) {
+		e := newTestEngine()
+		hello := &hello{}
+		e.Load(hello)
+		ctx := e.nodes.context(e.baseContext(), hello)
+		key := NewStateKey[*stateKeyUser]("/test/statekey/pointer")
+
+		user := &stateKeyUser{Name: "Maxence"}
+		key.Set(ctx, user)
+		require.Same(t, user, key.Get(ctx))
+
+		var v *stateKeyUser
+		key.Observe(ctx, &v)
+		require.Same(t, user, v)
+	})
+
+	t.Run("interface state is set and retrieved", func(t *testing.T) {
+		e := newTestEngine()
+		hello := &hello{}
+		e.Load(hello)
+		ctx := e.nodes.context(e.baseContext(), hello)
+		key := NewStateKey[any]("/test/statekey/interface")
+
+		key.Set(ctx, stateKeyUser{Name: "Maxence"})
+		require.Equal(t, stateKeyUser{Name: "Maxence"}, key.Get(ctx))
+
+		var v any
+		key.Observe(ctx, &v)
+		require.Equal(t, stateKeyUser{Name: "Maxence"}, v)
+	})
+
+	t.Run("computed state is retrieved", func(t *testing.T) {
+		e := newTestEngine()
+		ctx := e.baseContext()
+		count := NewStateKey[int]("/test/statekey/count")
+		label := NewStateKey[string]("/test/statekey/label")
+
+		count.Set(ctx, 2)
+		label.Compute(ctx, func(ctx Context) string {
+			if count.Get(ctx) > 1 {
+				return "many"
+			}
+			return "one"
+		})
+		require.Equal(t, "many", label.Get(ctx))
+
+		count.Set(ctx, 1)
+		require.Equal(t, "one", label.Get(ctx))
+	})
+
+	t.Run("observer is notified with old and new values", func(t *testing.T) {
+		e := newTestEngine()
+		hello := &hello{}
+		e.Load(hello)
+		ctx := e.nodes.context(e.baseContext(), hello)
+		key := NewStateKey[string]("/test/statekey/observe")
+
+		key.Set(ctx, "hello")
+
+		var v string
+		var changes [][2]string
+		key.Observe(ctx, &v).
+			OnChange(func(old, new string) {
+				changes = append(changes, [2]string{old, new})
+			}).
+			While(func() bool { return true })
+		require.Equal(t, "hello", v)
+
+		key.Set(ctx, "bye")
+		e.ConsumeAll()
+		key.Set(ctx, "hi")
+		e.ConsumeAll()
+
+		require.Equal(t, "hi", v)
+		require.Equal(t, [][2]string{
+			{"hello", "bye"},
+			{"bye", "hi"},
+		}, changes)
+	})
+}
+
+type stateKeyUser struct {
+	Name string
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"encoding/json"
+	"reflect"
 	"sync"
 
 	"github.com/maxence-charriere/go-app/v10/pkg/errors"
@@ -105,13 +106,22 @@ func (s *memoryStorage) Len() int {
 }
 
 func (s *memoryStorage) ForEach(f func(key string)) {
+	s.mu.RLock()
+	keys := make([]string, 0, len(s.data))
 	for k := range s.data {
+		keys = append(keys, k)
+	}
+	s.mu.RUnlock()
+
+	for _, k := range keys {
 		f(k)
 	}
 }
 
 func (s *memoryStorage) Contains(k string) bool {
+	s.mu.RLock()
 	_, ok := s.data[k]
+	s.mu.RUnlock()
 	return ok
 }
 
@@ -203,3 +213,230 @@ func (s *jsStorage) Contains(k string) bool {
 	defer s.mutex.Unlock()
 	return !Window().Get(s.name).Call("getItem", k).IsNull()
 }
+
+// NewIndexedDBStorage returns a browser storage backed by the IndexedDB
+// database with the given name. Compared to the local storage, it offers
+// larger quotas and stores byte slices as binary values instead of JSON.
+//
+// Storage operations block until IndexedDB completes them, which makes them
+// safe to call from components and action handlers. They must not be called
+// from functions that are synchronously invoked by JavaScript, such as
+// functions created with FuncOf, since IndexedDB completes operations on the
+// JavaScript event loop.
+//
+// An in-memory storage is returned when not running in a web browser.
+func NewIndexedDBStorage(database string) BrowserStorage {
+	if IsServer {
+		return newMemoryStorage()
+	}
+	return newIndexedDBStorage(database)
+}
+
+const indexedDBObjectStore = "items"
+
+type indexedDBStorage struct {
+	name  string
+	mutex sync.Mutex
+	db    Value
+}
+
+func newIndexedDBStorage(name string) *indexedDBStorage {
+	return &indexedDBStorage{name: name}
+}
+
+func (s *indexedDBStorage) Set(k string, v any) error {
+	var value any
+	if b, ok := v.([]byte); ok {
+		array := Window().Get("Uint8Array").New(len(b))
+		CopyBytesToJS(array, b)
+		value = array
+	} else {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		value = string(b)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, err := s.do("readwrite", "put", value, k); err != nil {
+		return errors.New("setting storage value failed").
+			WithTag("storage-type", "indexedDB").
+			WithTag("database", s.name).
+			WithTag("key", k).
+			Wrap(err)
+	}
+	return nil
+}
+
+func (s *indexedDBStorage) Get(k string, v any) error {
+	s.mutex.Lock()
+	value, err := s.do("readonly", "get", k)
+	s.mutex.Unlock()
+	if err != nil {
+		return errors.New("getting storage value failed").
+			WithTag("storage-type", "indexedDB").
+			WithTag("database", s.name).
+			WithTag("key", k).
+			Wrap(err)
+	}
+
+	switch value.Type() {
+	case TypeUndefined, TypeNull:
+		return nil
+
+	case TypeString:
+		return json.Unmarshal([]byte(value.String()), v)
+	}
+
+	b := make([]byte, value.Length())
+	CopyBytesToGo(b, value)
+
+	receiver, ok := v.(*[]byte)
+	if !ok {
+		return errors.New("binary value receiver is not a byte slice pointer").
+			WithTag("key", k).
+			WithTag("receiver-type", reflect.TypeOf(v))
+	}
+	*receiver = b
+	return nil
+}
+
+func (s *indexedDBStorage) Del(k string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, err := s.do("readwrite", "delete", k); err != nil {
+		Log(errors.New("deleting storage value failed").
+			WithTag("storage-type", "indexedDB").
+			WithTag("database", s.name).
+			WithTag("key", k).
+			Wrap(err))
+	}
+}
+
+func (s *indexedDBStorage) Clear() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, err := s.do("readwrite", "clear"); err != nil {
+		Log(errors.New("clearing storage failed").
+			WithTag("storage-type", "indexedDB").
+			WithTag("database", s.name).
+			Wrap(err))
+	}
+}
+
+func (s *indexedDBStorage) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	count, err := s.do("readonly", "count")
+	if err != nil {
+		Log(errors.New("counting storage values failed").
+			WithTag("storage-type", "indexedDB").
+			WithTag("database", s.name).
+			Wrap(err))
+		return 0
+	}
+	return count.Int()
+}
+
+func (s *indexedDBStorage) ForEach(f func(key string)) {
+	s.mutex.Lock()
+	keys, err := s.do("readonly", "getAllKeys")
+	s.mutex.Unlock()
+	if err != nil {
+		Log(errors.New("getting storage keys failed").
+			WithTag("storage-type", "indexedDB").
+			WithTag("database", s.name).
+			Wrap(err))
+		return
+	}
+
+	for i, length := 0, keys.Length(); i < length; i++ {
+		f(keys.Index(i).String())
+	}
+}
+
+func (s *indexedDBStorage) Contains(k string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	count, err := s.do("readonly", "count", k)
+	return err == nil && count.Int() != 0
+}
+
+// do calls the given method on the object store within a new transaction and
+// waits for the resulting request to complete.
+func (s *indexedDBStorage) do(mode, method string, args ...any) (res Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New("indexedDB call panicked").
+				WithTag("method", method).
+				WithTag("panic", r)
+		}
+	}()
+
+	db, err := s.database()
+	if err != nil {
+		return nil, err
+	}
+
+	store := db.
+		Call("transaction", indexedDBObjectStore, mode).
+		Call("objectStore", indexedDBObjectStore)
+	return awaitIndexedDBRequest(store.Call(method, args...))
+}
+
+func (s *indexedDBStorage) database() (Value, error) {
+	if s.db != nil {
+		return s.db, nil
+	}
+
+	request := Window().Get("indexedDB").Call("open", s.name, 1)
+	upgrade := FuncOf(func(Value, []Value) any {
+		request.Get("result").Call("createObjectStore", indexedDBObjectStore)
+		return nil
+	})
+	defer upgrade.Release()
+	request.Set("onupgradeneeded", upgrade)
+
+	db, err := awaitIndexedDBRequest(request)
+	if err != nil {
+		return nil, errors.New("opening indexedDB database failed").
+			WithTag("database", s.name).
+			Wrap(err)
+	}
+	s.db = db
+	return db, nil
+}
+
+func awaitIndexedDBRequest(request Value) (Value, error) {
+	done := make(chan error, 1)
+
+	onSuccess := FuncOf(func(Value, []Value) any {
+		done <- nil
+		return nil
+	})
+	defer onSuccess.Release()
+
+	onError := FuncOf(func(Value, []Value) any {
+		requestErr := request.Get("error")
+		done <- errors.New("indexedDB request failed").
+			WithTag("name", requestErr.Get("name").String()).
+			WithTag("message", requestErr.Get("message").String())
+		return nil
+	})
+	defer onError.Release()
+
+	request.Set("onsuccess", onSuccess)
+	request.Set("onerror", onError)
+
+	if err := <-done; err != nil {
+		return nil, err
+	}
+	return request.Get("result"), nil
+}
@@ -208,3 +208,29 @@ func testBrowserStorageContains(t *testing.T, s BrowserStorage) {
 	s.Set("lightsaber", true)
 	require.True(t, s.Contains("lightsaber"))
 }
+
+func TestIndexedDBStorage(t *testing.T) {
+	t.Run("memory storage is used on server", func(t *testing.T) {
+		if IsClient {
+			t.Skip()
+		}
+		require.IsType(t, &memoryStorage{}, NewIndexedDBStorage("test"))
+	})
+
+	t.Run("indexedDB storage", func(t *testing.T) {
+		testSkipNonWasm(t)
+		testBrowserStorage(t, newIndexedDBStorage("test"))
+	})
+
+	t.Run("binary value is set and get", func(t *testing.T) {
+		s := NewIndexedDBStorage("test")
+
+		err := s.Set("/binary", []byte("hello"))
+		require.NoError(t, err)
+
+		var b []byte
+		err = s.Get("/binary", &b)
+		require.NoError(t, err)
+		require.Equal(t, []byte("hello"), b)
+	})
+}